@@ -12,6 +12,9 @@ project {
     # examples used within documentation (prose)
     "examples/**",
 
+    # documentation templates (prose)
+    "templates/**",
+
     # GitHub issue template configuration
     ".github/ISSUE_TEMPLATE/*.yml",
 
@@ -1,3 +1,3 @@
 provider "scaffolding" {
-  # example configuration here
+  endpoint = "https://api.example.com"
 }
@@ -1 +1,4 @@
-terraform import scaffolding_example.test "id-123"
\ No newline at end of file
+# Examples can be imported by identifier alone, or by project, region and
+# identifier.
+terraform import scaffolding_example.test "id-123"
+terraform import scaffolding_example.test "project-a/region-a/id-123"
@@ -0,0 +1,7 @@
+import {
+  to = scaffolding_example.example
+  identity = {
+    id      = "example-123"
+    project = "default"
+  }
+}
@@ -0,0 +1,145 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// OAuth2Config configures authentication using the OAuth 2.0 client
+// credentials grant.
+type OAuth2Config struct {
+	// TokenURL is the URL of the token endpoint of the authorization server.
+	TokenURL string
+
+	// ClientID is the client identifier issued by the authorization server.
+	ClientID string
+
+	// ClientSecret is the client secret issued by the authorization server.
+	ClientSecret string
+
+	// Scopes are the optional scopes to request.
+	Scopes []string
+}
+
+// ErrMultipleAuthModes is returned when more than one authentication mode is
+// configured.
+var ErrMultipleAuthModes = errors.New("only one of token, username/password or oauth2 authentication may be configured")
+
+// AuthModes returns the names of the authentication modes configured in cfg.
+func (cfg Config) AuthModes() []string {
+	var modes []string
+
+	if cfg.Token != "" {
+		modes = append(modes, "token")
+	}
+
+	if cfg.Username != "" || cfg.Password != "" {
+		modes = append(modes, "basic")
+	}
+
+	if cfg.OAuth2 != nil {
+		modes = append(modes, "oauth2")
+	}
+
+	return modes
+}
+
+// secrets returns the configured credential values.
+func (cfg Config) secrets() []string {
+	secrets := []string{cfg.Token, cfg.Password}
+
+	if cfg.OAuth2 != nil {
+		secrets = append(secrets, cfg.OAuth2.ClientSecret)
+	}
+
+	return secrets
+}
+
+// authTransport wraps base with a transport which authenticates each request
+// using the authentication mode configured in cfg. Requests are sent
+// unauthenticated when no mode is configured.
+func (cfg Config) authTransport(base http.RoundTripper) (http.RoundTripper, error) {
+	if len(cfg.AuthModes()) > 1 {
+		return nil, ErrMultipleAuthModes
+	}
+
+	switch {
+	case cfg.Token != "":
+		return &headerTransport{
+			base:   base,
+			header: "Authorization",
+			value:  "Bearer " + cfg.Token,
+		}, nil
+	case cfg.Username != "" || cfg.Password != "":
+		if cfg.Username == "" || cfg.Password == "" {
+			return nil, errors.New("username and password must be configured together")
+		}
+
+		return &basicAuthTransport{
+			base:     base,
+			username: cfg.Username,
+			password: cfg.Password,
+		}, nil
+	case cfg.OAuth2 != nil:
+		if cfg.OAuth2.TokenURL == "" || cfg.OAuth2.ClientID == "" || cfg.OAuth2.ClientSecret == "" {
+			return nil, errors.New("oauth2 token_url, client_id and client_secret are required")
+		}
+
+		ccConfig := clientcredentials.Config{
+			ClientID:     cfg.OAuth2.ClientID,
+			ClientSecret: cfg.OAuth2.ClientSecret,
+			TokenURL:     cfg.OAuth2.TokenURL,
+			Scopes:       cfg.OAuth2.Scopes,
+			AuthStyle:    oauth2.AuthStyleAutoDetect,
+		}
+
+		// Token requests use the same underlying transport as API requests
+		// so that they share any TLS and proxy configuration. The returned
+		// token source caches the token and transparently fetches a new one
+		// shortly before it expires.
+		tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: base})
+
+		return &oauth2.Transport{
+			Source: ccConfig.TokenSource(tokenCtx),
+			Base:   base,
+		}, nil
+	}
+
+	return base, nil
+}
+
+// headerTransport sets a static header on each request.
+type headerTransport struct {
+	base   http.RoundTripper
+	header string
+	value  string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(t.header, t.value)
+
+	return t.base.RoundTrip(req)
+}
+
+// basicAuthTransport sets HTTP basic authentication credentials on each
+// request.
+type basicAuthTransport struct {
+	base     http.RoundTripper
+	username string
+	password string
+}
+
+func (t *basicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(t.username, t.password)
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,139 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+)
+
+func TestClient_Auth(t *testing.T) {
+	testCases := map[string]struct {
+		config   client.Config
+		expected string
+	}{
+		"none": {
+			expected: "",
+		},
+		"token": {
+			config:   client.Config{Token: "secret-token"},
+			expected: "Bearer secret-token",
+		},
+		"basic": {
+			config:   client.Config{Username: "user", Password: "pass"},
+			expected: "Basic dXNlcjpwYXNz",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Authorization")
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			t.Cleanup(server.Close)
+
+			testCase.config.Endpoint = server.URL
+
+			c, err := client.New(testCase.config)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err := c.DeleteExample(t.Context(), "example-1"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.expected {
+				t.Errorf("expected Authorization header %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestClient_AuthOAuth2Refresh(t *testing.T) {
+	var issued atomic.Int32
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unexpected error parsing token request: %s", err)
+		}
+
+		if got := r.PostForm.Get("grant_type"); got != "client_credentials" {
+			t.Errorf("expected client_credentials grant, got %q", got)
+		}
+
+		if got := r.PostForm.Get("scope"); got != "read write" {
+			t.Errorf("expected scope %q, got %q", "read write", got)
+		}
+
+		n := issued.Add(1)
+
+		w.Header().Set("Content-Type", "application/json")
+		// Tokens which expire within the refresh window are considered
+		// expired immediately, forcing a refresh before every request.
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("token-%d", n),
+			"token_type":   "Bearer",
+			"expires_in":   1,
+		})
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	var received []string
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(apiServer.Close)
+
+	c, err := client.New(client.Config{
+		Endpoint: apiServer.URL,
+		OAuth2: &client.OAuth2Config{
+			TokenURL:     tokenServer.URL,
+			ClientID:     "client",
+			ClientSecret: "secret",
+			Scopes:       []string{"read", "write"},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for range 2 {
+		if err := c.DeleteExample(t.Context(), "example-1"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	expected := []string{"Bearer token-1", "Bearer token-2"}
+
+	if fmt.Sprint(received) != fmt.Sprint(expected) {
+		t.Errorf("expected Authorization headers %v, got %v", expected, received)
+	}
+}
+
+func TestNew_MultipleAuthModes(t *testing.T) {
+	_, err := client.New(client.Config{
+		Token:    "secret-token",
+		Username: "user",
+		Password: "pass",
+	})
+
+	if !errors.Is(err, client.ErrMultipleAuthModes) {
+		t.Fatalf("expected ErrMultipleAuthModes, got: %v", err)
+	}
+}
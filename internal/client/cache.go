@@ -0,0 +1,288 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
+)
+
+// dryRunParameter is the query parameter asking the API to validate a request
+// without applying it.
+const dryRunParameter = "dry_run"
+
+// cacheTransport caches successful GET responses for the lifetime of the
+// client, so that objects read repeatedly during a Terraform run are fetched
+// once. Concurrent identical requests share a single round-trip, and cached
+// responses are invalidated whenever a request which may change the object
+// is sent through the transport. Dry runs never change objects, so they leave
+// cached responses in place.
+type cacheTransport struct {
+	base http.RoundTripper
+
+	// identity distinguishes the credentials requests are sent with, so that
+	// responses are never shared between identities.
+	identity string
+
+	// logContext returns a context with the HTTP logging subsystem, so that
+	// cache hits are logged like round-trips.
+	logContext func(context.Context) context.Context
+
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+
+	// calls holds the shared round-trips in flight, keyed like entries.
+	calls map[string]*sharedCall
+
+	// generation is incremented on each invalidation, so that responses to
+	// requests which were in flight during an invalidation are not cached.
+	generation uint64
+}
+
+// sharedCall is a round-trip shared by concurrent identical requests. It is
+// cancelled once every caller waiting for it has given up.
+type sharedCall struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	waiters int
+}
+
+// cachedResponse is a response held by cacheTransport.
+type cachedResponse struct {
+	path       string
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+func newCacheTransport(base http.RoundTripper, identity string, logContext func(context.Context) context.Context) *cacheTransport {
+	return &cacheTransport{
+		base:       base,
+		identity:   identity,
+		logContext: logContext,
+		entries:    make(map[string]*cachedResponse),
+		calls:      make(map[string]*sharedCall),
+	}
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.URL.Query().Has(dryRunParameter) {
+		return t.base.RoundTrip(req)
+	}
+
+	if req.Method != http.MethodGet {
+		resp, err := t.base.RoundTrip(req)
+
+		// Invalidate regardless of the outcome, as a failed request may still
+		// have changed the object.
+		t.invalidate(req.URL.Path)
+
+		return resp, err
+	}
+
+	// Requests asking for a fresh response bypass the cache, and invalidate
+	// cached responses which may predate it.
+	if req.Header.Get("Cache-Control") == "no-cache" {
+		resp, err := t.base.RoundTrip(req)
+
+		t.invalidate(req.URL.Path)
+
+		return resp, err
+	}
+
+	key := t.identity + " " + req.URL.String()
+
+	t.mu.Lock()
+	cached, ok := t.entries[key]
+	generation := t.generation
+	t.mu.Unlock()
+
+	if ok {
+		tflog.SubsystemDebug(t.logContext(req.Context()), LogSubsystemHTTP, "Using cached HTTP response", map[string]any{
+			"tf_http_req_method": req.Method,
+			"tf_http_req_uri":    req.URL.Redacted(),
+		})
+
+		return cached.response(req), nil
+	}
+
+	call := t.join(req.Context(), key)
+	shared := req.Clone(call.ctx)
+
+	ch := t.group.DoChan(key, func() (any, error) {
+		defer t.finish(key, call)
+
+		resp, err := t.base.RoundTrip(shared)
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result := &cachedResponse{
+			path:       req.URL.Path,
+			statusCode: resp.StatusCode,
+			header:     resp.Header,
+			body:       body,
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			t.mu.Lock()
+
+			if t.generation == generation {
+				t.entries[key] = result
+			}
+
+			t.mu.Unlock()
+		}
+
+		return result, nil
+	})
+
+	var shareResult singleflight.Result
+
+	select {
+	case <-req.Context().Done():
+		t.leave(key, call)
+
+		return nil, req.Context().Err()
+	case shareResult = <-ch:
+	}
+
+	if shareResult.Err != nil {
+		return nil, shareResult.Err
+	}
+
+	result, ok := shareResult.Val.(*cachedResponse)
+
+	if !ok {
+		return nil, fmt.Errorf("unexpected cached response type: %T", shareResult.Val)
+	}
+
+	return result.response(req), nil
+}
+
+// join returns the shared round-trip for key, starting a new one when none is
+// in flight, and counts the caller as waiting for it. The round-trip is not
+// cancelled with the caller which started it, but keeps that caller's values
+// such as its logger.
+func (t *cacheTransport) join(ctx context.Context, key string) *sharedCall {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	call, ok := t.calls[key]
+
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &sharedCall{ctx: callCtx, cancel: cancel}
+		t.calls[key] = call
+	}
+
+	call.waiters++
+
+	return call
+}
+
+// leave stops a caller waiting for the shared round-trip for key. When no
+// callers remain, the round-trip is cancelled and forgotten, so that a hung
+// request is not joined by later callers.
+func (t *cacheTransport) leave(key string, call *sharedCall) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	call.waiters--
+
+	if call.waiters > 0 {
+		return
+	}
+
+	call.cancel()
+
+	if t.calls[key] == call {
+		delete(t.calls, key)
+		t.group.Forget(key)
+	}
+}
+
+// finish releases the shared round-trip for key once it has completed.
+func (t *cacheTransport) finish(key string, call *sharedCall) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	call.cancel()
+
+	if t.calls[key] == call {
+		delete(t.calls, key)
+	}
+}
+
+// invalidate removes cached responses for the object at path, for objects
+// beneath it, and for collections containing it.
+func (t *cacheTransport) invalidate(path string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.generation++
+
+	for key, entry := range t.entries {
+		if pathContains(entry.path, path) || pathContains(path, entry.path) {
+			delete(t.entries, key)
+		}
+	}
+}
+
+// response returns a new response to req with the cached content.
+func (c *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.statusCode, http.StatusText(c.statusCode)),
+		StatusCode:    c.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(c.body)),
+		ContentLength: int64(len(c.body)),
+		Request:       req,
+	}
+}
+
+// pathContains returns whether path is parent or one of its descendants.
+func pathContains(parent string, path string) bool {
+	parent = strings.TrimSuffix(parent, "/")
+
+	return path == parent || strings.HasPrefix(path, parent+"/")
+}
+
+// cacheIdentity returns an opaque identifier for the credentials in cfg.
+// Secrets are hashed so that they are not held in cache keys.
+func (cfg Config) cacheIdentity() string {
+	parts := []string{cfg.Token, cfg.Username, cfg.Password}
+
+	if cfg.OAuth2 != nil {
+		parts = append(parts, cfg.OAuth2.TokenURL, cfg.OAuth2.ClientID, cfg.OAuth2.ClientSecret)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,324 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client/clienttest"
+)
+
+func TestClient_CacheReads(t *testing.T) {
+	testCases := map[string]struct {
+		cacheReads bool
+		expected   int32
+	}{
+		"enabled": {
+			cacheReads: true,
+			expected:   1,
+		},
+		"disabled": {
+			expected: 3,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server, gets := newCountingServer(t)
+			server.PutExample(client.Example{ID: "example-1"})
+
+			c := newTestClient(t, client.Config{Endpoint: server.URL, CacheReads: testCase.cacheReads})
+
+			for range 3 {
+				if _, err := c.GetExample(t.Context(), "example-1"); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			if got := gets.Load(); got != testCase.expected {
+				t.Errorf("expected %d GET requests, got %d", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestClient_CacheReadsConcurrent(t *testing.T) {
+	var gets atomic.Int32
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets.Add(1)
+		<-release
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"example-1"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c := newTestClient(t, client.Config{Endpoint: server.URL, CacheReads: true})
+
+	var wg sync.WaitGroup
+
+	for range 5 {
+		wg.Go(func() {
+			example, err := c.GetExample(t.Context(), "example-1")
+
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+
+			if example.ID != "example-1" {
+				t.Errorf("expected example-1, got: %s", example.ID)
+			}
+		})
+	}
+
+	// Give every request the chance to join the one in flight.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	wg.Wait()
+
+	if got := gets.Load(); got != 1 {
+		t.Errorf("expected 1 GET request, got %d", got)
+	}
+}
+
+func TestClient_CacheReadsConcurrentCanceled(t *testing.T) {
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		<-release
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"example-1"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c := newTestClient(t, client.Config{Endpoint: server.URL, CacheReads: true})
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	first := make(chan error, 1)
+
+	go func() {
+		_, err := c.GetExample(ctx, "example-1")
+		first <- err
+	}()
+
+	<-received
+
+	second := make(chan error, 1)
+
+	go func() {
+		_, err := c.GetExample(t.Context(), "example-1")
+		second <- err
+	}()
+
+	// Give the second request the chance to join the one in flight, then
+	// cancel the caller which started it.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected canceled error for the canceled caller, got: %v", err)
+	}
+
+	close(release)
+
+	if err := <-second; err != nil {
+		t.Errorf("unexpected error for the waiting caller: %s", err)
+	}
+}
+
+func TestClient_CacheReadsConcurrentTimeout(t *testing.T) {
+	var gets atomic.Int32
+
+	abandoned := make(chan struct{})
+	done := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first request hangs until it is abandoned.
+		if gets.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+				close(abandoned)
+			case <-done:
+			}
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"example-1"}`))
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(done) })
+
+	c := newTestClient(t, client.Config{Endpoint: server.URL, CacheReads: true})
+
+	var wg sync.WaitGroup
+
+	for range 2 {
+		wg.Go(func() {
+			ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+			defer cancel()
+
+			if _, err := c.GetExample(ctx, "example-1"); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected deadline exceeded, got: %v", err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	select {
+	case <-abandoned:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the shared request to be canceled once every caller gave up")
+	}
+
+	// Later reads are not joined to the abandoned request.
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, err := c.GetExample(ctx, "example-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := gets.Load(); got != 2 {
+		t.Errorf("expected 2 GET requests, got %d", got)
+	}
+}
+
+func TestClient_CacheReadsInvalidation(t *testing.T) {
+	server, gets := newCountingServer(t)
+	server.PutExample(client.Example{ID: "example-1", Defaulted: "before"})
+
+	c := newTestClient(t, client.Config{Endpoint: server.URL, CacheReads: true})
+
+	if _, err := c.GetExample(t.Context(), "example-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := c.ListExamples(t.Context(), nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := c.UpdateExample(t.Context(), client.Example{ID: "example-1", Defaulted: "after"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	example, err := c.GetExample(t.Context(), "example-1")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if example.Defaulted != "after" {
+		t.Errorf("expected updated example, got: %+v", example)
+	}
+
+	examples, err := c.ListExamples(t.Context(), nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(examples) != 1 || examples[0].Defaulted != "after" {
+		t.Errorf("expected updated example in list, got: %+v", examples)
+	}
+
+	if got := gets.Load(); got != 4 {
+		t.Errorf("expected 4 GET requests, got %d", got)
+	}
+
+	// Unrelated requests do not invalidate cached objects.
+	if err := c.InvokeExampleAction(t.Context(), client.ExampleActionRequest{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := c.GetExample(t.Context(), "example-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := gets.Load(); got != 4 {
+		t.Errorf("expected 4 GET requests, got %d", got)
+	}
+}
+
+func TestClient_CacheReadsDryRun(t *testing.T) {
+	server, gets := newCountingServer(t)
+	server.PutExample(client.Example{ID: "example-1"})
+
+	c := newTestClient(t, client.Config{Endpoint: server.URL, CacheReads: true})
+
+	if _, err := c.GetExample(t.Context(), "example-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Validating a create or an update does not change any object.
+	for _, example := range []client.Example{{}, {ID: "example-1", Defaulted: "changed"}} {
+		if err := c.ValidateExample(t.Context(), example); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if _, err := c.GetExample(t.Context(), "example-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := gets.Load(); got != 1 {
+		t.Errorf("expected 1 GET request, got %d", got)
+	}
+}
+
+func TestClient_CacheReadsNotFound(t *testing.T) {
+	server, gets := newCountingServer(t)
+
+	c := newTestClient(t, client.Config{Endpoint: server.URL, CacheReads: true})
+
+	for range 2 {
+		if _, err := c.GetExample(t.Context(), "example-1"); !client.IsNotFound(err) {
+			t.Fatalf("expected not found error, got: %v", err)
+		}
+	}
+
+	if got := gets.Load(); got != 2 {
+		t.Errorf("expected unsuccessful responses not to be cached, got %d GET requests", got)
+	}
+}
+
+// newCountingServer returns a clienttest.Server and the number of GET
+// requests it has received.
+func newCountingServer(t *testing.T) (*clienttest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var gets atomic.Int32
+
+	server := clienttest.NewServer(t)
+	backend := server.Config.Handler
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets.Add(1)
+		}
+
+		backend.ServeHTTP(w, r)
+	})
+
+	return server, &gets
+}
@@ -0,0 +1,65 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"slices"
+)
+
+// Features which may be offered by the API. Servers roll features out
+// independently, so clients must check for a feature before using it.
+const (
+	// FeatureExampleTags is offered by servers which store tags on example
+	// objects. Other servers reject example objects with tags.
+	FeatureExampleTags = "example_tags"
+
+	// FeatureExampleDryRun is offered by servers which validate example
+	// objects without changing them, as done by ValidateExample.
+	FeatureExampleDryRun = "example_dry_run"
+)
+
+// Capabilities describes the API version and features offered by a server.
+type Capabilities struct {
+	// APIVersion is the version of the API implemented by the server.
+	APIVersion string `json:"api_version"`
+
+	// Features are the optional features offered by the server.
+	Features []string `json:"features"`
+}
+
+// Supports reports whether the server offers the named feature.
+func (c Capabilities) Supports(feature string) bool {
+	return slices.Contains(c.Features, feature)
+}
+
+// DiscoverCapabilities fetches the capabilities of the server and stores
+// them on the client, where they are returned by Capabilities. Servers which
+// predate capability discovery are treated as offering no features.
+func (c *Client) DiscoverCapabilities(ctx context.Context) (Capabilities, error) {
+	var result Capabilities
+
+	err := c.do(ctx, http.MethodGet, "capabilities", nil, &result)
+
+	if err != nil && !IsNotFound(err) {
+		return Capabilities{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.capabilities = result
+
+	return result, nil
+}
+
+// Capabilities returns the server capabilities stored by the last call to
+// DiscoverCapabilities. No features are offered before discovery.
+func (c *Client) Capabilities() Capabilities {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.capabilities
+}
@@ -0,0 +1,102 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client/clienttest"
+)
+
+func TestClient_DiscoverCapabilities(t *testing.T) {
+	testCases := map[string]struct {
+		capabilities     *client.Capabilities
+		legacy           bool
+		expectAPIVersion string
+		expectTags       bool
+	}{
+		"all-features": {
+			expectAPIVersion: "1.1",
+			expectTags:       true,
+		},
+		"no-tags": {
+			capabilities: &client.Capabilities{
+				APIVersion: "1.0",
+			},
+			expectAPIVersion: "1.0",
+			expectTags:       false,
+		},
+		"legacy": {
+			legacy:           true,
+			expectAPIVersion: "",
+			expectTags:       false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var endpoint string
+
+			if testCase.legacy {
+				// Servers which predate discovery have no capabilities endpoint.
+				server := httptest.NewServer(http.NotFoundHandler())
+				t.Cleanup(server.Close)
+
+				endpoint = server.URL
+			} else {
+				server := clienttest.NewServer(t)
+
+				if testCase.capabilities != nil {
+					server.SetCapabilities(*testCase.capabilities)
+				}
+
+				endpoint = server.URL
+			}
+
+			c, err := client.New(client.Config{Endpoint: endpoint})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if c.Capabilities().Supports(client.FeatureExampleTags) {
+				t.Fatal("expected no features before discovery")
+			}
+
+			got, err := c.DiscoverCapabilities(t.Context())
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got.APIVersion != testCase.expectAPIVersion {
+				t.Errorf("expected API version %q, got %q", testCase.expectAPIVersion, got.APIVersion)
+			}
+
+			if supported := c.Capabilities().Supports(client.FeatureExampleTags); supported != testCase.expectTags {
+				t.Errorf("expected %s support %t, got %t", client.FeatureExampleTags, testCase.expectTags, supported)
+			}
+		})
+	}
+}
+
+func TestClient_DiscoverCapabilities_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.New(client.Config{Endpoint: server.URL})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := c.DiscoverCapabilities(t.Context()); err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
@@ -0,0 +1,409 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package client implements a typed client for the scaffolding API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultEndpoint is the API endpoint used when none is configured.
+const DefaultEndpoint = "http://localhost:8080"
+
+// Config describes how a Client connects to the API.
+type Config struct {
+	// Endpoint is the base URL of the API, such as https://api.example.com.
+	// DefaultEndpoint is used when empty.
+	Endpoint string
+
+	// HTTPClient is the underlying client used to perform requests.
+	// A client using http.DefaultTransport is used when nil. The given client
+	// is copied rather than modified when adding authentication.
+	HTTPClient *http.Client
+
+	// TLS configures TLS for connections made by the Client. The Go defaults
+	// are used when nil.
+	TLS *TLSConfig
+
+	// Token is a static bearer token sent with each request.
+	Token string
+
+	// Username and Password are HTTP basic authentication credentials sent
+	// with each request.
+	Username string
+	Password string
+
+	// OAuth2 configures the OAuth 2.0 client credentials grant. Tokens are
+	// fetched on first use and refreshed automatically before they expire.
+	OAuth2 *OAuth2Config
+
+	// MaxRetries is the number of times a request which fails with a
+	// transient error is retried. Only idempotent requests, and POST requests
+	// carrying an idempotency key, are retried. Conditional requests are only
+	// retried after network errors. Requests are not retried when zero.
+	MaxRetries int
+
+	// RetryMaxWait is the longest time waited between retries, including
+	// waits requested by the API with a Retry-After header. Responses asking
+	// for a longer wait are returned without retrying. DefaultRetryMaxWait is
+	// used when zero.
+	RetryMaxWait time.Duration
+
+	// RequestsPerSecond limits the sustained rate of requests, including
+	// retries, sent by the Client. The rate is unlimited when zero.
+	RequestsPerSecond float64
+
+	// MaxConcurrentRequests limits the number of requests the Client has in
+	// flight at once. Further requests wait for an earlier request to
+	// complete. The number of requests is unlimited when zero.
+	MaxConcurrentRequests int
+
+	// SensitiveFields are the names of request and response body fields
+	// whose values are masked when round-trips are logged, in addition to
+	// common credential fields. Configured credentials are always masked.
+	SensitiveFields []string
+
+	// CacheReads enables caching of successful GET responses for the lifetime
+	// of the client. Concurrent identical requests share a single round-trip,
+	// and cached responses are invalidated by any other request to the same
+	// object or its collection.
+	CacheReads bool
+
+	// UserAgent is sent as the User-Agent header of each request, including
+	// OAuth 2.0 token requests. The Go default is sent when empty.
+	UserAgent string
+
+	// PollInterval is the wait before the first poll of a long-running
+	// operation, which doubles with each subsequent poll up to
+	// PollMaxInterval. DefaultPollInterval and DefaultPollMaxInterval are used
+	// when zero.
+	PollInterval    time.Duration
+	PollMaxInterval time.Duration
+}
+
+// ParseEndpoint parses an API endpoint, which must be an absolute http or
+// https URL. The endpoint may include a base path, but not a query or
+// fragment.
+func ParseEndpoint(endpoint string) (*url.URL, error) {
+	u, err := url.Parse(endpoint)
+
+	if err != nil {
+		return nil, fmt.Errorf("parsing endpoint %q: %w", endpoint, err)
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("endpoint %q must be an absolute URL with a scheme and host", endpoint)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("endpoint %q must use the http or https scheme, got %q", endpoint, u.Scheme)
+	}
+
+	if u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("endpoint %q must not include a query or fragment", endpoint)
+	}
+
+	return u, nil
+}
+
+// Client is a typed client for the scaffolding API. It is safe for
+// concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+
+	pollInterval    time.Duration
+	pollMaxInterval time.Duration
+
+	mu           sync.RWMutex
+	capabilities Capabilities
+}
+
+// New returns a Client configured from cfg.
+func New(cfg Config) (*Client, error) {
+	endpoint := cfg.Endpoint
+
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+
+	baseURL, err := ParseEndpoint(endpoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure relative references resolve beneath any path in the endpoint.
+	if !strings.HasSuffix(baseURL.Path, "/") {
+		baseURL.Path += "/"
+	}
+
+	httpClient := http.Client{}
+
+	if cfg.HTTPClient != nil {
+		httpClient = *cfg.HTTPClient
+	}
+
+	transport := httpClient.Transport
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if cfg.TLS != nil {
+		transport, err = cfg.TLS.tlsTransport(transport)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Logging wraps the underlying transport directly so that every attempt,
+	// including OAuth 2.0 token requests, is logged exactly as sent.
+	logging := newLoggingTransport(transport, cfg.SensitiveFields, cfg.secrets())
+	transport = logging
+
+	if cfg.UserAgent != "" {
+		transport = &headerTransport{
+			base:   transport,
+			header: "User-Agent",
+			value:  cfg.UserAgent,
+		}
+	}
+
+	transport, err = cfg.authTransport(transport)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transport = newRateLimitTransport(transport, cfg.RequestsPerSecond, cfg.MaxConcurrentRequests)
+
+	if cfg.MaxRetries > 0 {
+		retryMaxWait := cfg.RetryMaxWait
+
+		if retryMaxWait <= 0 {
+			retryMaxWait = DefaultRetryMaxWait
+		}
+
+		transport = &retryTransport{
+			base:       transport,
+			maxRetries: cfg.MaxRetries,
+			maxWait:    retryMaxWait,
+		}
+	}
+
+	// Caching wraps all other transports so that cache hits are neither
+	// rate limited nor retried.
+	if cfg.CacheReads {
+		transport = newCacheTransport(transport, cfg.cacheIdentity(), logging.subsystemContext)
+	}
+
+	httpClient.Transport = transport
+
+	pollInterval := cfg.PollInterval
+
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	pollMaxInterval := cfg.PollMaxInterval
+
+	if pollMaxInterval <= 0 {
+		pollMaxInterval = DefaultPollMaxInterval
+	}
+
+	return &Client{
+		baseURL:         baseURL,
+		httpClient:      &httpClient,
+		pollInterval:    pollInterval,
+		pollMaxInterval: max(pollInterval, pollMaxInterval),
+	}, nil
+}
+
+// Endpoint returns the base URL requests are sent to.
+func (c *Client) Endpoint() string {
+	return c.baseURL.String()
+}
+
+// APIError is returned when the API responds with an unsuccessful status code.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Message is the error message returned by the API, if any.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("API returned status %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is an APIError with a 404 Not Found status.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsPreconditionFailed reports whether err is an APIError with a 412
+// Precondition Failed status, returned when an object changed since its ETag
+// was read.
+func IsPreconditionFailed(err error) bool {
+	var apiErr *APIError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusPreconditionFailed
+}
+
+// etagged is implemented by response bodies which record the ETag header of
+// the response.
+type etagged interface {
+	setETag(etag string)
+}
+
+// errorResponse is the body returned by the API alongside error status codes.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// requestOption customizes an API request before it is sent.
+type requestOption func(*http.Request)
+
+// withIdempotencyKey sets a unique idempotency key on the request, allowing
+// it to be retried safely even when it is not idempotent.
+func withIdempotencyKey() requestOption {
+	return func(req *http.Request) {
+		req.Header.Set(IdempotencyKeyHeader, rand.Text())
+	}
+}
+
+// withIfMatch makes the request conditional on the target object having the
+// given ETag. Requests are unconditional when etag is empty.
+func withIfMatch(etag string) requestOption {
+	return func(req *http.Request) {
+		if etag != "" {
+			req.Header.Set("If-Match", etag)
+		}
+	}
+}
+
+// withoutCache prevents the response to the request from being read from the
+// cache of successful GET responses.
+func withoutCache() requestOption {
+	return func(req *http.Request) {
+		req.Header.Set("Cache-Control", "no-cache")
+	}
+}
+
+// do sends a request to the API, encoding in as the JSON request body when
+// non-nil and decoding the JSON response body into out when non-nil.
+func (c *Client) do(ctx context.Context, method string, ref string, in any, out any, opts ...requestOption) error {
+	op, err := c.doAsync(ctx, method, ref, in, out, opts...)
+
+	if err != nil {
+		return err
+	}
+
+	if op != nil {
+		return fmt.Errorf("unexpected long-running operation %s", op.ID)
+	}
+
+	return nil
+}
+
+// doAsync sends a request to the API like do, except that when the API
+// accepts the request as a long-running operation, the operation is returned
+// rather than decoded into out.
+func (c *Client) doAsync(ctx context.Context, method string, ref string, in any, out any, opts ...requestOption) (*Operation, error) {
+	u, err := c.baseURL.Parse(ref)
+
+	if err != nil {
+		return nil, fmt.Errorf("building request URL: %w", err)
+	}
+
+	var body io.Reader
+
+	if in != nil {
+		b, err := json.Marshal(in)
+
+		if err != nil {
+			return nil, fmt.Errorf("encoding request body: %w", err)
+		}
+
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if name := moduleName(ctx); name != "" {
+		req.Header.Set(ModuleNameHeader, name)
+	}
+
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+
+		var errResp errorResponse
+
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
+			apiErr.Message = errResp.Error
+		}
+
+		return nil, apiErr
+	}
+
+	if resp.StatusCode == http.StatusAccepted {
+		return c.acceptedOperation(resp)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, fmt.Errorf("decoding response body: %w", err)
+	}
+
+	if out, ok := out.(etagged); ok {
+		out.setETag(resp.Header.Get("ETag"))
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,169 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+)
+
+func TestNew(t *testing.T) {
+	testCases := map[string]struct {
+		endpoint    string
+		expected    string
+		expectError bool
+	}{
+		"default": {
+			expected: client.DefaultEndpoint + "/",
+		},
+		"host": {
+			endpoint: "https://api.example.com",
+			expected: "https://api.example.com/",
+		},
+		"path": {
+			endpoint: "https://api.example.com/v1",
+			expected: "https://api.example.com/v1/",
+		},
+		"missing-scheme": {
+			endpoint:    "api.example.com",
+			expectError: true,
+		},
+		"invalid": {
+			endpoint:    "https://api.example.com:port",
+			expectError: true,
+		},
+		"unsupported-scheme": {
+			endpoint:    "ftp://api.example.com",
+			expectError: true,
+		},
+		"query": {
+			endpoint:    "https://api.example.com/v1?region=eu",
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c, err := client.New(client.Config{Endpoint: testCase.endpoint})
+
+			if testCase.expectError {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := c.Endpoint(); got != testCase.expected {
+				t.Errorf("expected endpoint %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"configurable_attribute is invalid"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.New(client.Config{Endpoint: server.URL})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, err = c.GetExample(t.Context(), "example-1")
+
+	expected := "API returned status 400: configurable_attribute is invalid"
+
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got: %v", expected, err)
+	}
+
+	if client.IsNotFound(err) {
+		t.Error("expected IsNotFound to be false")
+	}
+}
+
+func TestClient_UserAgent(t *testing.T) {
+	var userAgent string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.New(client.Config{
+		Endpoint:  server.URL,
+		UserAgent: "terraform-provider-scaffolding/1.2.3 Terraform/1.14.0",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := c.DeleteExample(t.Context(), "example-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := "terraform-provider-scaffolding/1.2.3 Terraform/1.14.0"; userAgent != expected {
+		t.Errorf("expected User-Agent %q, got %q", expected, userAgent)
+	}
+}
+
+func TestClient_ModuleName(t *testing.T) {
+	var moduleNames []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		moduleNames = append(moduleNames, r.Header.Get(client.ModuleNameHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.New(client.Config{
+		Endpoint: server.URL,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := c.DeleteExample(client.WithModuleName(t.Context(), "example-module/1.2.3"), "example-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := c.DeleteExample(t.Context(), "example-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []string{"example-module/1.2.3", ""}; !slices.Equal(moduleNames, expected) {
+		t.Errorf("expected %s headers %q, got %q", client.ModuleNameHeader, expected, moduleNames)
+	}
+}
+
+// newTestClient returns a client with the given configuration, failing the
+// test when it is invalid.
+func newTestClient(t *testing.T, cfg client.Config) *client.Client {
+	t.Helper()
+
+	c, err := client.New(cfg)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return c
+}
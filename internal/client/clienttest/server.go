@@ -0,0 +1,52 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package clienttest provides an in-memory stand-in for the scaffolding API
+// for use in unit and acceptance tests.
+package clienttest
+
+import (
+	"encoding/pem"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client/mockapi"
+)
+
+// Server serves an in-memory mockapi.Backend over HTTP.
+type Server struct {
+	*httptest.Server
+	*mockapi.Backend
+}
+
+// NewServer starts a Server which is closed when the test completes.
+func NewServer(t *testing.T) *Server {
+	t.Helper()
+
+	s := &Server{Backend: mockapi.NewBackend()}
+	s.Server = httptest.NewServer(s.Backend)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// NewTLSServer starts a Server using TLS which is closed when the test
+// completes. Its certificate is issued by the CA returned by CACertPEM.
+func NewTLSServer(t *testing.T) *Server {
+	t.Helper()
+
+	s := &Server{Backend: mockapi.NewBackend()}
+	s.Server = httptest.NewTLSServer(s.Backend)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// CACertPEM returns the PEM-encoded certificate of a server started with
+// NewTLSServer, which clients must trust to connect.
+func (s *Server) CACertPEM() string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: s.Certificate().Raw,
+	}))
+}
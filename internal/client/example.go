@@ -0,0 +1,200 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Example is an example object managed by the API.
+type Example struct {
+	ID                    string            `json:"id,omitempty"`
+	Project               string            `json:"project,omitempty"`
+	Region                string            `json:"region,omitempty"`
+	ConfigurableAttribute *string           `json:"configurable_attribute,omitempty"`
+	Defaulted             string            `json:"defaulted,omitempty"`
+	Tags                  map[string]string `json:"tags,omitempty"`
+
+	// Revision is assigned by the API, starting at 1 and incremented by
+	// every update of the object.
+	Revision int64 `json:"revision,omitempty"`
+
+	// ETag identifies the revision of the object returned by the API. It is
+	// sent by UpdateExample as a precondition, so that changes made since the
+	// object was read are not overwritten.
+	ETag string `json:"-"`
+}
+
+func (e *Example) setETag(etag string) {
+	e.ETag = etag
+}
+
+// ExampleActionRequest is the body sent when invoking the example action.
+type ExampleActionRequest struct {
+	ConfigurableAttribute *string `json:"configurable_attribute,omitempty"`
+}
+
+// ExampleTokenRequest is the body sent when issuing an example token.
+type ExampleTokenRequest struct {
+	ConfigurableAttribute string `json:"configurable_attribute"`
+}
+
+// ExampleToken is a short-lived credential issued by the API.
+type ExampleToken struct {
+	Value string `json:"value"`
+}
+
+// CreateExample creates a new example object and returns it as stored by
+// the API, including its server-assigned identifier. The request carries an
+// idempotency key so that it can be retried without creating duplicates.
+// When the API creates the object asynchronously, CreateExample waits for the
+// operation to complete.
+func (c *Client) CreateExample(ctx context.Context, example Example) (*Example, error) {
+	var result Example
+
+	op, err := c.doAsync(ctx, http.MethodPost, "examples", example, &result, withIdempotencyKey())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if op != nil {
+		return c.waitForExample(ctx, op)
+	}
+
+	return &result, nil
+}
+
+// GetExample returns the example object with the given identifier.
+func (c *Client) GetExample(ctx context.Context, id string) (*Example, error) {
+	var result Example
+
+	if err := c.do(ctx, http.MethodGet, examplePath(id), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListExamples returns all example objects, optionally filtered to those
+// whose configurable attribute equals configurableAttribute.
+func (c *Client) ListExamples(ctx context.Context, configurableAttribute *string) ([]Example, error) {
+	ref := "examples"
+
+	if configurableAttribute != nil {
+		ref += "?" + url.Values{"configurable_attribute": {*configurableAttribute}}.Encode()
+	}
+
+	var result []Example
+
+	if err := c.do(ctx, http.MethodGet, ref, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateExample replaces the example object identified by example.ID and
+// returns it as stored by the API. When example.ETag is set, the object is
+// only replaced if it is unchanged since it was read, otherwise an error
+// satisfying IsPreconditionFailed is returned. When the API updates the
+// object asynchronously, UpdateExample waits for the operation to complete.
+func (c *Client) UpdateExample(ctx context.Context, example Example) (*Example, error) {
+	if example.ID == "" {
+		return nil, fmt.Errorf("example ID is required")
+	}
+
+	var result Example
+
+	op, err := c.doAsync(ctx, http.MethodPut, examplePath(example.ID), example, &result, withIfMatch(example.ETag))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if op != nil {
+		return c.waitForExample(ctx, op)
+	}
+
+	return &result, nil
+}
+
+// ValidateExample asks the API to validate example as it would be created,
+// or updated when example.ID is set, without changing any object. The error
+// describing why the API rejected example is returned, if any. Only servers
+// offering FeatureExampleDryRun support validation.
+func (c *Client) ValidateExample(ctx context.Context, example Example) error {
+	method, ref := http.MethodPost, "examples"
+
+	if example.ID != "" {
+		method, ref = http.MethodPut, examplePath(example.ID)
+	}
+
+	return c.do(ctx, method, ref+"?"+dryRunParameter+"=true", example, nil)
+}
+
+// DeleteExample deletes the example object with the given identifier,
+// waiting for the operation to complete when the API deletes the object
+// asynchronously.
+func (c *Client) DeleteExample(ctx context.Context, id string) error {
+	return c.DeleteExampleIfMatch(ctx, id, "")
+}
+
+// DeleteExampleIfMatch deletes the example object with the given identifier
+// like DeleteExample, but only if its ETag matches etag, otherwise an error
+// satisfying IsPreconditionFailed is returned. The object is deleted
+// unconditionally when etag is empty.
+func (c *Client) DeleteExampleIfMatch(ctx context.Context, id string, etag string) error {
+	op, err := c.doAsync(ctx, http.MethodDelete, examplePath(id), nil, nil, withIfMatch(etag))
+
+	if err != nil || op == nil {
+		return err
+	}
+
+	_, err = c.WaitForOperation(ctx, op)
+
+	return err
+}
+
+// waitForExample waits for op to complete and returns the example object it
+// changed. The object is read without the cache, as cached responses may
+// predate the change.
+func (c *Client) waitForExample(ctx context.Context, op *Operation) (*Example, error) {
+	op, err := c.WaitForOperation(ctx, op)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result Example
+
+	if err := c.do(ctx, http.MethodGet, examplePath(op.TargetID), nil, &result, withoutCache()); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// IssueExampleToken issues a new short-lived example token.
+func (c *Client) IssueExampleToken(ctx context.Context, req ExampleTokenRequest) (*ExampleToken, error) {
+	var result ExampleToken
+
+	if err := c.do(ctx, http.MethodPost, "tokens", req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// InvokeExampleAction invokes the example action.
+func (c *Client) InvokeExampleAction(ctx context.Context, req ExampleActionRequest) error {
+	return c.do(ctx, http.MethodPost, "actions/example", req, nil)
+}
+
+func examplePath(id string) string {
+	return "examples/" + url.PathEscape(id)
+}
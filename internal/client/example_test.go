@@ -0,0 +1,226 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client/clienttest"
+)
+
+func TestClient_ExampleLifecycle(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	c, err := client.New(client.Config{Endpoint: server.URL})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx := t.Context()
+
+	created, err := c.CreateExample(ctx, client.Example{
+		ConfigurableAttribute: stringPointer("one"),
+		Defaulted:             "default",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected create error: %s", err)
+	}
+
+	if created.ID == "" {
+		t.Fatal("expected server-assigned ID")
+	}
+
+	got, err := c.GetExample(ctx, created.ID)
+
+	if err != nil {
+		t.Fatalf("unexpected read error: %s", err)
+	}
+
+	if got.ConfigurableAttribute == nil || *got.ConfigurableAttribute != "one" {
+		t.Errorf("expected configurable_attribute %q, got %v", "one", got.ConfigurableAttribute)
+	}
+
+	got.ConfigurableAttribute = stringPointer("two")
+
+	updated, err := c.UpdateExample(ctx, *got)
+
+	if err != nil {
+		t.Fatalf("unexpected update error: %s", err)
+	}
+
+	if *updated.ConfigurableAttribute != "two" {
+		t.Errorf("expected configurable_attribute %q, got %q", "two", *updated.ConfigurableAttribute)
+	}
+
+	list, err := c.ListExamples(ctx, stringPointer("two"))
+
+	if err != nil {
+		t.Fatalf("unexpected list error: %s", err)
+	}
+
+	if len(list) != 1 || list[0].ID != created.ID {
+		t.Errorf("expected list to contain only %q, got %v", created.ID, list)
+	}
+
+	if err := c.DeleteExample(ctx, created.ID); err != nil {
+		t.Fatalf("unexpected delete error: %s", err)
+	}
+
+	_, err = c.GetExample(ctx, created.ID)
+
+	if !client.IsNotFound(err) {
+		t.Errorf("expected not found error after delete, got: %v", err)
+	}
+}
+
+func TestClient_ExampleETag(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	c, err := client.New(client.Config{Endpoint: server.URL})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx := t.Context()
+
+	created, err := c.CreateExample(ctx, client.Example{Defaulted: "one"})
+
+	if err != nil {
+		t.Fatalf("unexpected create error: %s", err)
+	}
+
+	if created.ETag == "" {
+		t.Fatal("expected created example to have an ETag")
+	}
+
+	// Change the example outside of the client.
+	changed := *created
+	changed.Defaulted = "changed"
+	server.PutExample(changed)
+
+	created.Defaulted = "two"
+
+	if _, err := c.UpdateExample(ctx, *created); !client.IsPreconditionFailed(err) {
+		t.Fatalf("expected precondition failed error for stale update, got: %v", err)
+	}
+
+	if err := c.DeleteExampleIfMatch(ctx, created.ID, created.ETag); !client.IsPreconditionFailed(err) {
+		t.Fatalf("expected precondition failed error for stale delete, got: %v", err)
+	}
+
+	current, err := c.GetExample(ctx, created.ID)
+
+	if err != nil {
+		t.Fatalf("unexpected read error: %s", err)
+	}
+
+	if current.ETag == created.ETag {
+		t.Fatal("expected the ETag to change with the example")
+	}
+
+	current.Defaulted = "two"
+
+	updated, err := c.UpdateExample(ctx, *current)
+
+	if err != nil {
+		t.Fatalf("unexpected update error: %s", err)
+	}
+
+	if err := c.DeleteExampleIfMatch(ctx, updated.ID, updated.ETag); err != nil {
+		t.Fatalf("unexpected delete error: %s", err)
+	}
+}
+
+func TestClient_ValidateExample(t *testing.T) {
+	testCases := map[string]struct {
+		example        client.Example
+		capabilities   *client.Capabilities
+		expectedStatus int
+	}{
+		"create": {
+			example: client.Example{Region: "eu-west"},
+		},
+		"update": {
+			example: client.Example{ID: "example-1", Defaulted: "changed"},
+		},
+		"invalid-create": {
+			example:        client.Example{Region: "moon"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		"invalid-update": {
+			example:        client.Example{ID: "example-1", Project: "other"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		"unsupported": {
+			example:        client.Example{},
+			capabilities:   &client.Capabilities{APIVersion: "1.0"},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := clienttest.NewServer(t)
+			server.PutExample(client.Example{ID: "example-1", Project: "default", Region: "global", Revision: 1})
+
+			if testCase.capabilities != nil {
+				server.SetCapabilities(*testCase.capabilities)
+			}
+
+			c, err := client.New(client.Config{Endpoint: server.URL})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			err = c.ValidateExample(t.Context(), testCase.example)
+
+			var apiErr *client.APIError
+
+			switch {
+			case testCase.expectedStatus == 0 && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case testCase.expectedStatus != 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != testCase.expectedStatus):
+				t.Fatalf("expected API error with status %d, got: %v", testCase.expectedStatus, err)
+			}
+
+			// Validation never changes the stored examples.
+			if example, _ := server.Example("example-1"); example.Defaulted != "" || example.Revision != 1 {
+				t.Errorf("expected example to be unchanged, got: %+v", example)
+			}
+
+			if _, ok := server.Example("example-2"); ok {
+				t.Error("expected no example to be created")
+			}
+		})
+	}
+}
+
+func TestClient_InvokeExampleAction(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	c, err := client.New(client.Config{Endpoint: server.URL})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := c.InvokeExampleAction(t.Context(), client.ExampleActionRequest{ConfigurableAttribute: stringPointer("example")}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if actions := server.Actions(); len(actions) != 1 || *actions[0].ConfigurableAttribute != "example" {
+		t.Errorf("expected one recorded action, got %v", actions)
+	}
+}
+
+func stringPointer(s string) *string {
+	return &s
+}
@@ -0,0 +1,182 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// LogSubsystemHTTP is the tflog subsystem API round-trips are logged
+	// under.
+	LogSubsystemHTTP = "scaffolding.http"
+
+	// logLevelEnvVar and logLevelEnvSubsystem combine into the
+	// TF_LOG_PROVIDER_SCAFFOLDING_HTTP environment variable, which sets the
+	// level of LogSubsystemHTTP independently of other provider logs.
+	logLevelEnvVar       = "TF_LOG_PROVIDER_SCAFFOLDING"
+	logLevelEnvSubsystem = "HTTP"
+)
+
+// defaultSensitiveFields are request and response body fields which are
+// always masked in logs, such as those of OAuth 2.0 token requests.
+var defaultSensitiveFields = []string{
+	"access_token",
+	"client_secret",
+	"password",
+	"refresh_token",
+	"token",
+}
+
+// sensitiveHeaders are request and response headers which are always masked
+// in logs.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+}
+
+// loggingTransport logs each round-trip under LogSubsystemHTTP with its
+// method, URL, headers, body, status and latency. Credentials are masked
+// using tflog masking so that they never reach the log output.
+type loggingTransport struct {
+	base http.RoundTripper
+
+	// bodyMasks match sensitive fields in JSON and form-encoded bodies.
+	bodyMasks []*regexp.Regexp
+
+	// secrets are configured credential values masked wherever they appear.
+	secrets []string
+}
+
+// newLoggingTransport returns base wrapped with a loggingTransport which
+// masks the values of the given body fields and secrets.
+func newLoggingTransport(base http.RoundTripper, sensitiveFields []string, secrets []string) *loggingTransport {
+	t := &loggingTransport{
+		base: base,
+	}
+
+	for _, field := range slices.Concat(defaultSensitiveFields, sensitiveFields) {
+		quoted := regexp.QuoteMeta(field)
+
+		t.bodyMasks = append(t.bodyMasks,
+			// JSON string values, such as "token":"value".
+			regexp.MustCompile(`"`+quoted+`"\s*:\s*"(?:[^"\\]|\\.)*"`),
+			// Form-encoded values, such as token=value.
+			regexp.MustCompile(`(?:^|&)`+quoted+`=[^&]*`),
+		)
+	}
+
+	for _, secret := range secrets {
+		if secret != "" {
+			t.secrets = append(t.secrets, secret)
+		}
+	}
+
+	return t
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := t.subsystemContext(req.Context())
+
+	reqFields := map[string]any{
+		"tf_http_req_method": req.Method,
+		"tf_http_req_uri":    req.URL.Redacted(),
+	}
+
+	addHeaderFields(reqFields, "tf_http_req_header_", req.Header)
+
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			reqFields["tf_http_req_body"] = readBody(body)
+		}
+	}
+
+	tflog.SubsystemDebug(ctx, LogSubsystemHTTP, "Sending HTTP request", reqFields)
+
+	start := time.Now()
+
+	resp, err := t.base.RoundTrip(req)
+
+	resFields := map[string]any{
+		"tf_http_req_method":     req.Method,
+		"tf_http_req_uri":        req.URL.Redacted(),
+		"tf_http_res_latency_ms": time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		resFields["error"] = err.Error()
+
+		tflog.SubsystemError(ctx, LogSubsystemHTTP, "HTTP request failed", resFields)
+
+		return resp, err
+	}
+
+	resFields["tf_http_res_status_code"] = resp.StatusCode
+
+	addHeaderFields(resFields, "tf_http_res_header_", resp.Header)
+
+	// Buffer the body so it can be both logged and returned to the caller.
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	resFields["tf_http_res_body"] = string(body)
+
+	tflog.SubsystemDebug(ctx, LogSubsystemHTTP, "Received HTTP response", resFields)
+
+	return resp, nil
+}
+
+// subsystemContext returns ctx with the HTTP logging subsystem and its
+// masking configured.
+func (t *loggingTransport) subsystemContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystemHTTP, tflog.WithLevelFromEnv(logLevelEnvVar, logLevelEnvSubsystem), tflog.WithRootFields())
+
+	var headerKeys []string
+
+	for _, header := range sensitiveHeaders {
+		headerKeys = append(headerKeys, "tf_http_req_header_"+header, "tf_http_res_header_"+header)
+	}
+
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, LogSubsystemHTTP, headerKeys...)
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, LogSubsystemHTTP, t.bodyMasks...)
+	ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, LogSubsystemHTTP, t.secrets...)
+
+	return ctx
+}
+
+// addHeaderFields adds a field for each header, prefixed with prefix.
+func addHeaderFields(fields map[string]any, prefix string, header http.Header) {
+	for name, values := range header {
+		fields[prefix+name] = strings.Join(values, ", ")
+	}
+}
+
+// readBody reads and closes body, returning its contents as a string.
+func readBody(body io.ReadCloser) string {
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
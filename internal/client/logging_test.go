@@ -0,0 +1,124 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+)
+
+func TestClient_Logging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "cookie-secret"})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"example-1","defaulted":"visible","custom_secret":"response-secret"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.New(client.Config{
+		Endpoint:        server.URL,
+		Token:           "bearer-secret",
+		SensitiveFields: []string{"custom_secret"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var output bytes.Buffer
+
+	ctx := tflogtest.RootLogger(t.Context(), &output)
+
+	if _, err := c.GetExample(ctx, "example-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+
+	if err != nil {
+		t.Fatalf("unexpected error decoding logs: %s", err)
+	}
+
+	var messages []string
+
+	for _, entry := range entries {
+		if entry["@module"] != "provider."+client.LogSubsystemHTTP {
+			continue
+		}
+
+		message, _ := entry["@message"].(string)
+		messages = append(messages, message)
+
+		if message == "Received HTTP response" {
+			if got := entry["tf_http_res_status_code"]; got != float64(http.StatusOK) {
+				t.Errorf("expected status code field 200, got: %v", got)
+			}
+
+			if _, ok := entry["tf_http_res_latency_ms"]; !ok {
+				t.Error("expected latency field")
+			}
+
+			if body, _ := entry["tf_http_res_body"].(string); !strings.Contains(body, `"defaulted":"visible"`) {
+				t.Errorf("expected unmasked response body fields, got: %s", body)
+			}
+		}
+	}
+
+	if expected := []string{"Sending HTTP request", "Received HTTP response"}; strings.Join(messages, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected messages %v, got %v", expected, messages)
+	}
+
+	for _, secret := range []string{"bearer-secret", "cookie-secret", "response-secret"} {
+		if strings.Contains(output.String(), secret) {
+			t.Errorf("expected %q to be masked in logs: %s", secret, output.String())
+		}
+	}
+}
+
+func TestClient_LoggingLevelFromEnv(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_SCAFFOLDING_HTTP", "ERROR")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"example-1"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.New(client.Config{Endpoint: server.URL, CacheReads: true})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var output bytes.Buffer
+
+	ctx := tflogtest.RootLogger(t.Context(), &output)
+
+	// The second read is served from the cache, which logs under the same
+	// subsystem.
+	for range 2 {
+		if _, err := c.GetExample(ctx, "example-1"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if err := c.DeleteExample(ctx, "example-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if strings.Contains(output.String(), client.LogSubsystemHTTP) {
+		t.Errorf("expected no debug logs from the HTTP subsystem, got: %s", output.String())
+	}
+}
@@ -0,0 +1,779 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package mockapi implements an in-process fake of the scaffolding API, used
+// by tests and by the provider mock mode to work without a server.
+package mockapi
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+)
+
+// Ensure Backend can serve requests both over the network and in-process.
+var _ http.Handler = &Backend{}
+var _ http.RoundTripper = &Backend{}
+
+// Backend is a fake implementation of the scaffolding API. It serves requests
+// as an http.Handler, or in-process as an http.RoundTripper so that a client
+// can use it without a server.
+type Backend struct {
+	mu           sync.Mutex
+	path         string
+	state        state
+	capabilities client.Capabilities
+	async        *Async
+
+	// changed records that a GET request changed the state, such as by
+	// completing an operation, so that it is saved.
+	changed bool
+}
+
+// Async configures a Backend to accept changes to examples as long-running
+// operations, standing in for servers which complete changes asynchronously.
+type Async struct {
+	// Polls is the number of status requests answered before an operation
+	// completes.
+	Polls int
+
+	// Failure is the reason operations fail with. Operations succeed when
+	// empty.
+	Failure string
+}
+
+// DefaultCapabilities are the capabilities offered by a Backend unless
+// changed with SetCapabilities, including every feature.
+var DefaultCapabilities = client.Capabilities{
+	APIVersion: "1.1",
+	Features:   []string{client.FeatureExampleTags, client.FeatureExampleDryRun},
+}
+
+// Regions are the regions examples can be located in.
+var Regions = []string{DefaultRegion, "eu-west", "us-east"}
+
+const (
+	// DefaultProject is the project of examples created without one.
+	DefaultProject = "default"
+
+	// DefaultRegion is the region of examples created without one.
+	DefaultRegion = "global"
+)
+
+// state is the data held by a Backend, and the format of its state file.
+type state struct {
+	Examples        map[string]client.Example     `json:"examples"`
+	Actions         []client.ExampleActionRequest `json:"actions,omitempty"`
+	IdempotencyKeys map[string]string             `json:"idempotency_keys,omitempty"`
+	Operations      map[string]operation          `json:"operations,omitempty"`
+	NextID          int                           `json:"next_id"`
+	NextOperationID int                           `json:"next_operation_id,omitempty"`
+}
+
+// operation is a long-running change to an example, which is applied when
+// the operation completes.
+type operation struct {
+	Operation      client.Operation `json:"operation"`
+	Method         string           `json:"method"`
+	Example        client.Example   `json:"example"`
+	RemainingPolls int              `json:"remaining_polls"`
+	Failure        string           `json:"failure,omitempty"`
+}
+
+// NewBackend returns a Backend holding its data in memory.
+func NewBackend() *Backend {
+	return &Backend{
+		state:        newState(),
+		capabilities: DefaultCapabilities,
+	}
+}
+
+// NewFileBackend returns a Backend whose data is persisted to a JSON state
+// file at path, so that it survives between processes. The file is created
+// on the first change if it does not exist. Backends sharing a state file
+// apply changes one at a time, holding a lock on the file path with a .lock
+// suffix.
+func NewFileBackend(path string) (*Backend, error) {
+	b := &Backend{
+		path:         path,
+		capabilities: DefaultCapabilities,
+	}
+
+	if err := b.load(); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func newState() state {
+	return state{
+		Examples:        make(map[string]client.Example),
+		IdempotencyKeys: make(map[string]string),
+		Operations:      make(map[string]operation),
+	}
+}
+
+// PutExample stores example directly, bypassing the API. It can be used to
+// arrange objects which exist before a test runs or to simulate changes made
+// outside of Terraform. Changes are saved to the state file on a best-effort
+// basis.
+func (b *Backend) PutExample(example client.Example) {
+	b.modify(func() {
+		b.state.Examples[example.ID] = example
+	})
+}
+
+// Example returns the stored example object with the given identifier.
+func (b *Backend) Example(id string) (client.Example, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	_ = b.load()
+
+	example, ok := b.state.Examples[id]
+
+	return example, ok
+}
+
+// DeleteExample removes the example object with the given identifier,
+// bypassing the API.
+func (b *Backend) DeleteExample(id string) {
+	b.modify(func() {
+		delete(b.state.Examples, id)
+	})
+}
+
+// modify applies change to the state and saves it. The state file is locked
+// and reloaded first, so that changes made by other processes are not
+// overwritten.
+func (b *Backend) modify(change func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	unlock, err := b.lockStateFile()
+
+	if err != nil {
+		change()
+
+		return
+	}
+
+	defer unlock()
+
+	if err := b.load(); err != nil {
+		change()
+
+		return
+	}
+
+	change()
+
+	_ = b.save()
+}
+
+// SetCapabilities changes the capabilities offered by the backend, so that it
+// can stand in for servers which do not offer every feature. Requests using
+// features which are not offered are rejected.
+func (b *Backend) SetCapabilities(capabilities client.Capabilities) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.capabilities = capabilities
+}
+
+// SetAsync makes the backend accept changes to examples as long-running
+// operations as configured by async, or complete them immediately when nil.
+func (b *Backend) SetAsync(async *Async) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.async = async
+}
+
+// Actions returns the example action invocations received so far.
+func (b *Backend) Actions() []client.ExampleActionRequest {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]client.ExampleActionRequest(nil), b.state.Actions...)
+}
+
+// RoundTrip implements http.RoundTripper by serving req in-process.
+func (b *Backend) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	recorder := httptest.NewRecorder()
+
+	b.ServeHTTP(recorder, req)
+
+	resp := recorder.Result()
+	resp.Request = req
+
+	return resp, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (b *Backend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	unlock, err := b.lockStateFile()
+
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	defer unlock()
+
+	// Reload the state file so that changes made by other processes, such
+	// as other instances of the provider, are visible.
+	if err := b.load(); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	// Buffer the response so that it can be replaced with an error when
+	// changes cannot be saved.
+	recorder := httptest.NewRecorder()
+
+	b.changed = false
+	b.route(recorder, r)
+
+	if (r.Method != http.MethodGet || b.changed) && recorder.Code < http.StatusBadRequest {
+		if err := b.save(); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+
+			return
+		}
+	}
+
+	maps.Copy(w.Header(), recorder.Header())
+	w.WriteHeader(recorder.Code)
+
+	_, _ = w.Write(recorder.Body.Bytes())
+}
+
+func (b *Backend) route(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(segments) == 1 && segments[0] == "capabilities":
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, b.capabilities)
+	case len(segments) == 1 && segments[0] == "examples":
+		switch r.Method {
+		case http.MethodGet:
+			b.listExamples(w, r)
+		case http.MethodPost:
+			b.createExample(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	case len(segments) == 2 && segments[0] == "examples":
+		switch r.Method {
+		case http.MethodGet:
+			b.getExample(w, segments[1])
+		case http.MethodPut:
+			b.updateExample(w, r, segments[1])
+		case http.MethodDelete:
+			b.deleteExample(w, r, segments[1])
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	case len(segments) == 2 && segments[0] == "operations":
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+
+			return
+		}
+
+		b.getOperation(w, segments[1])
+	case len(segments) == 1 && segments[0] == "tokens":
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+
+			return
+		}
+
+		b.issueExampleToken(w, r)
+	case len(segments) == 2 && segments[0] == "actions" && segments[1] == "example":
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+
+			return
+		}
+
+		b.invokeExampleAction(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (b *Backend) listExamples(w http.ResponseWriter, r *http.Request) {
+	result := []client.Example{}
+
+	filter, filtered := r.URL.Query()["configurable_attribute"]
+
+	for _, example := range b.state.Examples {
+		if filtered && (example.ConfigurableAttribute == nil || *example.ConfigurableAttribute != filter[0]) {
+			continue
+		}
+
+		result = append(result, example)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (b *Backend) createExample(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get(client.IdempotencyKeyHeader)
+
+	// Replay the original response for retried requests.
+	if id, ok := b.state.IdempotencyKeys[key]; ok && key != "" {
+		b.replayCreate(w, id)
+
+		return
+	}
+
+	var example client.Example
+
+	if err := json.NewDecoder(r.Body).Decode(&example); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if !b.validExample(w, example) {
+		return
+	}
+
+	if example.Project == "" {
+		example.Project = DefaultProject
+	}
+
+	if example.Region == "" {
+		example.Region = DefaultRegion
+	}
+
+	if b.dryRun(w, r, example) {
+		return
+	}
+
+	b.state.NextID++
+	example.ID = "example-" + strconv.Itoa(b.state.NextID)
+	example.Revision = 1
+
+	if key != "" {
+		b.state.IdempotencyKeys[key] = example.ID
+	}
+
+	if b.async != nil {
+		b.accept(w, http.MethodPost, example)
+
+		return
+	}
+
+	b.state.Examples[example.ID] = example
+
+	writeExample(w, http.StatusCreated, example)
+}
+
+// replayCreate responds to a retried request creating the example with the
+// given identifier, with the operation creating it while one is in progress.
+func (b *Backend) replayCreate(w http.ResponseWriter, id string) {
+	if _, ok := b.state.Examples[id]; !ok {
+		for _, op := range b.state.Operations {
+			if op.Method == http.MethodPost && op.Operation.TargetID == id && !op.Operation.Done() {
+				w.Header().Set("Location", "operations/"+op.Operation.ID)
+				writeJSON(w, http.StatusAccepted, op.Operation)
+
+				return
+			}
+		}
+	}
+
+	b.getExample(w, id)
+}
+
+func (b *Backend) getExample(w http.ResponseWriter, id string) {
+	example, ok := b.state.Examples[id]
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "example "+id+" not found")
+
+		return
+	}
+
+	writeExample(w, http.StatusOK, example)
+}
+
+func (b *Backend) updateExample(w http.ResponseWriter, r *http.Request, id string) {
+	current, ok := b.state.Examples[id]
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "example "+id+" not found")
+
+		return
+	}
+
+	if !ifMatch(w, r, current) {
+		return
+	}
+
+	var example client.Example
+
+	if err := json.NewDecoder(r.Body).Decode(&example); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if !b.validExample(w, example) {
+		return
+	}
+
+	// Examples cannot move between projects or regions, so they may only be
+	// omitted or repeated.
+	if !unchanged(w, id, "project", &example.Project, current.Project) || !unchanged(w, id, "region", &example.Region, current.Region) {
+		return
+	}
+
+	example.ID = id
+	example.Revision = current.Revision + 1
+
+	if b.dryRun(w, r, example) {
+		return
+	}
+
+	if b.async != nil {
+		b.accept(w, http.MethodPut, example)
+
+		return
+	}
+
+	b.state.Examples[id] = example
+
+	writeExample(w, http.StatusOK, example)
+}
+
+func (b *Backend) deleteExample(w http.ResponseWriter, r *http.Request, id string) {
+	current, ok := b.state.Examples[id]
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "example "+id+" not found")
+
+		return
+	}
+
+	if !ifMatch(w, r, current) {
+		return
+	}
+
+	if b.async != nil {
+		b.accept(w, http.MethodDelete, client.Example{ID: id})
+
+		return
+	}
+
+	delete(b.state.Examples, id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// accept starts a long-running operation applying the change to example
+// made by a request with the given method.
+func (b *Backend) accept(w http.ResponseWriter, method string, example client.Example) {
+	b.state.NextOperationID++
+
+	op := operation{
+		Operation: client.Operation{
+			ID:       "operation-" + strconv.Itoa(b.state.NextOperationID),
+			Status:   client.OperationPending,
+			TargetID: example.ID,
+		},
+		Method:         method,
+		Example:        example,
+		RemainingPolls: b.async.Polls,
+		Failure:        b.async.Failure,
+	}
+
+	b.state.Operations[op.Operation.ID] = op
+
+	w.Header().Set("Location", "operations/"+op.Operation.ID)
+	writeJSON(w, http.StatusAccepted, op.Operation)
+}
+
+// getOperation returns the status of an operation, progressing it towards
+// completion with each request.
+func (b *Backend) getOperation(w http.ResponseWriter, id string) {
+	op, ok := b.state.Operations[id]
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "operation "+id+" not found")
+
+		return
+	}
+
+	switch {
+	case op.Operation.Done():
+	case op.RemainingPolls > 0:
+		op.RemainingPolls--
+		op.Operation.Status = client.OperationRunning
+	case op.Failure != "":
+		op.Operation.Status = client.OperationFailed
+		op.Operation.Error = op.Failure
+	default:
+		switch op.Method {
+		case http.MethodDelete:
+			delete(b.state.Examples, op.Example.ID)
+		default:
+			b.state.Examples[op.Example.ID] = op.Example
+		}
+
+		op.Operation.Status = client.OperationSucceeded
+	}
+
+	b.state.Operations[id] = op
+	b.changed = true
+
+	writeJSON(w, http.StatusOK, op.Operation)
+}
+
+// validExample writes an error and returns false when example uses features
+// which are not offered by the backend.
+func (b *Backend) validExample(w http.ResponseWriter, example client.Example) bool {
+	if len(example.Tags) > 0 && !b.capabilities.Supports(client.FeatureExampleTags) {
+		writeError(w, http.StatusBadRequest, `unknown field "tags"`)
+
+		return false
+	}
+
+	if example.Region != "" && !slices.Contains(Regions, example.Region) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown region %q", example.Region))
+
+		return false
+	}
+
+	return true
+}
+
+// dryRun writes example and returns true when the request only validates it,
+// so that it is not stored. Dry runs are rejected when the feature is not
+// offered.
+func (b *Backend) dryRun(w http.ResponseWriter, r *http.Request, example client.Example) bool {
+	if !r.URL.Query().Has("dry_run") {
+		return false
+	}
+
+	if !b.capabilities.Supports(client.FeatureExampleDryRun) {
+		writeError(w, http.StatusBadRequest, `unknown parameter "dry_run"`)
+
+		return true
+	}
+
+	writeExample(w, http.StatusOK, example)
+
+	return true
+}
+
+func (b *Backend) issueExampleToken(w http.ResponseWriter, r *http.Request) {
+	var req client.ExampleTokenRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, client.ExampleToken{
+		Value: "token-" + rand.Text(),
+	})
+}
+
+func (b *Backend) invokeExampleAction(w http.ResponseWriter, r *http.Request) {
+	var req client.ExampleActionRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	b.state.Actions = append(b.state.Actions, req)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// load replaces the in-memory state with the contents of the state file, if
+// any. A missing state file is treated as empty.
+func (b *Backend) load() error {
+	if b.path == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(b.path)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		b.state = newState()
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("reading mock API state file: %w", err)
+	}
+
+	s := newState()
+
+	if err := json.Unmarshal(content, &s); err != nil {
+		return fmt.Errorf("parsing mock API state file %s: %w", b.path, err)
+	}
+
+	b.state = s
+
+	return nil
+}
+
+// lockStateFile acquires an exclusive lock shared by every process using the
+// state file, if any, and returns a function releasing it.
+func (b *Backend) lockStateFile() (func(), error) {
+	if b.path == "" {
+		return func() {}, nil
+	}
+
+	f, err := os.OpenFile(b.path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+
+	if err != nil {
+		return nil, fmt.Errorf("opening mock API state lock file: %w", err)
+	}
+
+	if err := lockFile(f); err != nil {
+		f.Close()
+
+		return nil, fmt.Errorf("locking mock API state file %s: %w", b.path, err)
+	}
+
+	return func() {
+		_ = unlockFile(f)
+		f.Close()
+	}, nil
+}
+
+// save writes the in-memory state to the state file, if any. The file is
+// replaced atomically so that concurrent readers never see partial content.
+func (b *Backend) save() error {
+	if b.path == "" {
+		return nil
+	}
+
+	content, err := json.MarshalIndent(b.state, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(b.path), filepath.Base(b.path)+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), b.path)
+}
+
+// etag returns the ETag of the current revision of example, derived from its
+// content.
+func etag(example client.Example) string {
+	content, _ := json.Marshal(example)
+	sum := sha256.Sum256(content)
+
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// ifMatch writes an error and returns false when the request is conditional
+// on an ETag which does not match the current revision of example.
+func ifMatch(w http.ResponseWriter, r *http.Request, example client.Example) bool {
+	expected := r.Header.Get("If-Match")
+
+	if expected == "" || expected == "*" || expected == etag(example) {
+		return true
+	}
+
+	writeError(w, http.StatusPreconditionFailed, "example "+example.ID+" has changed")
+
+	return false
+}
+
+// unchanged writes an error and returns false when an immutable field of
+// example id is changed by an update. An omitted field is set to its current
+// value.
+func unchanged(w http.ResponseWriter, id string, field string, value *string, current string) bool {
+	switch *value {
+	case "":
+		*value = current
+	case current:
+	default:
+		writeError(w, http.StatusBadRequest, "example "+id+" cannot be moved to "+field+" "+*value)
+
+		return false
+	}
+
+	return true
+}
+
+func writeExample(w http.ResponseWriter, status int, example client.Example) {
+	w.Header().Set("ETag", etag(example))
+	writeJSON(w, status, example)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,275 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mockapi_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client/mockapi"
+)
+
+func TestFileBackend_Persistence(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "mock.json")
+
+	first := newClient(t, stateFile)
+
+	created, err := first.CreateExample(t.Context(), client.Example{Defaulted: "persisted"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// A second backend, such as one in a later Terraform run, sees the
+	// object created by the first.
+	second := newClient(t, stateFile)
+
+	got, err := second.GetExample(t.Context(), created.ID)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got.Defaulted != "persisted" {
+		t.Errorf("expected persisted object, got: %+v", got)
+	}
+
+	if err := second.DeleteExample(t.Context(), created.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := first.GetExample(t.Context(), created.ID); !client.IsNotFound(err) {
+		t.Errorf("expected not found error after deletion by another backend, got: %v", err)
+	}
+}
+
+func TestFileBackend_HelpersKeepOtherChanges(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "mock.json")
+
+	backend, err := mockapi.NewFileBackend(stateFile)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Another backend, such as one in another Terraform run, changes the
+	// state file after this backend loaded it.
+	created, err := newClient(t, stateFile).CreateExample(t.Context(), client.Example{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	backend.PutExample(client.Example{ID: "arranged"})
+	backend.DeleteExample("missing")
+
+	for _, id := range []string{created.ID, "arranged"} {
+		if _, ok := backend.Example(id); !ok {
+			t.Errorf("expected example %s to be kept", id)
+		}
+	}
+}
+
+func TestFileBackend_Concurrent(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "mock.json")
+
+	// Each client stands in for a separate Terraform run sharing the state
+	// file.
+	clients := []*client.Client{newClient(t, stateFile), newClient(t, stateFile)}
+
+	var wg sync.WaitGroup
+
+	for _, c := range clients {
+		for range 10 {
+			wg.Go(func() {
+				if _, err := c.CreateExample(t.Context(), client.Example{}); err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			})
+		}
+	}
+
+	wg.Wait()
+
+	examples, err := clients[0].ListExamples(t.Context(), nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(examples) != 20 {
+		t.Errorf("expected 20 examples, got %d", len(examples))
+	}
+}
+
+func TestFileBackend_Invalid(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "mock.json")
+
+	if err := os.WriteFile(stateFile, []byte("not json"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := mockapi.NewFileBackend(stateFile); err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
+
+func TestBackend_IssueExampleToken(t *testing.T) {
+	c, err := client.New(client.Config{
+		HTTPClient: &http.Client{Transport: mockapi.NewBackend()},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	first, err := c.IssueExampleToken(t.Context(), client.ExampleTokenRequest{ConfigurableAttribute: "example"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	second, err := c.IssueExampleToken(t.Context(), client.ExampleTokenRequest{ConfigurableAttribute: "example"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if first.Value == "" || first.Value == second.Value {
+		t.Errorf("expected distinct tokens, got %q and %q", first.Value, second.Value)
+	}
+}
+
+func TestBackend_SetCapabilities(t *testing.T) {
+	backend := mockapi.NewBackend()
+
+	c, err := client.New(client.Config{
+		HTTPClient: &http.Client{Transport: backend},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	example := client.Example{
+		Tags: map[string]string{"name": "example"},
+	}
+
+	if _, err := c.CreateExample(t.Context(), example); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	backend.SetCapabilities(client.Capabilities{APIVersion: "1.0"})
+
+	if _, err := c.CreateExample(t.Context(), example); err == nil {
+		t.Fatal("expected tags to be rejected, got no error")
+	}
+
+	if _, err := c.CreateExample(t.Context(), client.Example{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestBackend_ExampleLocation(t *testing.T) {
+	c, err := client.New(client.Config{
+		HTTPClient: &http.Client{Transport: mockapi.NewBackend()},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	created, err := c.CreateExample(t.Context(), client.Example{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if created.Project != mockapi.DefaultProject || created.Region != mockapi.DefaultRegion {
+		t.Errorf("expected default project and region, got: %+v", created)
+	}
+
+	// Updates which omit the project keep the current one.
+	updated, err := c.UpdateExample(t.Context(), client.Example{ID: created.ID})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if updated.Project != mockapi.DefaultProject || updated.Region != mockapi.DefaultRegion {
+		t.Errorf("expected project and region to be kept, got: %+v", updated)
+	}
+
+	if _, err := c.UpdateExample(t.Context(), client.Example{ID: created.ID, Project: "other"}); err == nil {
+		t.Fatal("expected project change to be rejected, got no error")
+	}
+
+	if _, err := c.UpdateExample(t.Context(), client.Example{ID: created.ID, Region: "other"}); err == nil {
+		t.Fatal("expected region change to be rejected, got no error")
+	}
+}
+
+func TestBackend_AsyncCreateReplay(t *testing.T) {
+	backend := mockapi.NewBackend()
+	backend.SetAsync(&mockapi.Async{Polls: 1})
+
+	var locations []string
+
+	// The retried request is answered with the operation started by the
+	// first, as the example does not exist until it completes.
+	for range 2 {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "http://mockapi/examples", strings.NewReader("{}"))
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		req.Header.Set(client.IdempotencyKeyHeader, "key-1")
+
+		resp, err := backend.RoundTrip(req)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("expected status %d, got %d", http.StatusAccepted, resp.StatusCode)
+		}
+
+		locations = append(locations, resp.Header.Get("Location"))
+	}
+
+	if locations[0] == "" || locations[0] != locations[1] {
+		t.Errorf("expected the same operation for both requests, got: %q", locations)
+	}
+}
+
+// newClient returns a client served in-process by a backend persisted to
+// stateFile.
+func newClient(t *testing.T, stateFile string) *client.Client {
+	t.Helper()
+
+	backend, err := mockapi.NewFileBackend(stateFile)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	c, err := client.New(client.Config{
+		HTTPClient: &http.Client{Transport: backend},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return c
+}
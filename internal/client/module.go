@@ -0,0 +1,29 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+)
+
+// ModuleNameHeader is the request header identifying the Terraform module
+// which manages the object a request is made for.
+const ModuleNameHeader = "X-Scaffolding-Module"
+
+// moduleNameKey is the context key of the module name.
+type moduleNameKey struct{}
+
+// WithModuleName returns ctx carrying the name of the Terraform module which
+// requests made with it are made on behalf of. The name is sent in the
+// ModuleNameHeader header.
+func WithModuleName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, moduleNameKey{}, name)
+}
+
+// moduleName returns the module name carried by ctx, if any.
+func moduleName(ctx context.Context) string {
+	name, _ := ctx.Value(moduleNameKey{}).(string)
+
+	return name
+}
@@ -0,0 +1,144 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// DefaultPollInterval is the wait before the first poll of a long-running
+	// operation when not configured. The wait doubles with each subsequent
+	// poll.
+	DefaultPollInterval = time.Second
+
+	// DefaultPollMaxInterval is the longest time waited between polls of a
+	// long-running operation when not configured.
+	DefaultPollMaxInterval = 15 * time.Second
+)
+
+// OperationStatus is the status of a long-running operation.
+type OperationStatus string
+
+const (
+	OperationPending   OperationStatus = "pending"
+	OperationRunning   OperationStatus = "running"
+	OperationSucceeded OperationStatus = "succeeded"
+	OperationFailed    OperationStatus = "failed"
+)
+
+// Operation is a long-running operation, returned by the API with a 202
+// Accepted status when a change completes asynchronously.
+type Operation struct {
+	ID     string          `json:"id"`
+	Status OperationStatus `json:"status"`
+
+	// Error is the reason the operation failed, if any.
+	Error string `json:"error,omitempty"`
+
+	// TargetID is the identifier of the object changed by the operation.
+	TargetID string `json:"target_id,omitempty"`
+
+	// location is the URL the operation status is polled from.
+	location string
+}
+
+// Done reports whether the operation has completed, successfully or not.
+func (o *Operation) Done() bool {
+	return o.Status == OperationSucceeded || o.Status == OperationFailed
+}
+
+// OperationError is returned when a long-running operation fails.
+type OperationError struct {
+	// OperationID is the identifier of the failed operation.
+	OperationID string
+
+	// Reason is the failure reason reported by the API, if any.
+	Reason string
+}
+
+func (e *OperationError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("operation %s failed", e.OperationID)
+	}
+
+	return fmt.Sprintf("operation %s failed: %s", e.OperationID, e.Reason)
+}
+
+// WaitForOperation polls the status of op, waiting with exponential backoff
+// between polls, until it completes or ctx is done. An *OperationError is
+// returned when the operation fails.
+func (c *Client) WaitForOperation(ctx context.Context, op *Operation) (*Operation, error) {
+	interval := c.pollInterval
+
+	for !op.Done() {
+		tflog.Debug(ctx, "Waiting for operation to complete", map[string]any{
+			"operation_id":     op.ID,
+			"operation_status": string(op.Status),
+			"poll_interval":    interval.String(),
+		})
+
+		timer := time.NewTimer(interval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, fmt.Errorf("waiting for operation %s: %w", op.ID, context.Cause(ctx))
+		case <-timer.C:
+		}
+
+		var next Operation
+
+		// Status changes while polling, so it is never read from the cache.
+		if err := c.do(ctx, http.MethodGet, op.location, nil, &next, withoutCache()); err != nil {
+			return nil, fmt.Errorf("polling operation %s: %w", op.ID, err)
+		}
+
+		next.location = op.location
+		op = &next
+
+		interval = min(interval*2, c.pollMaxInterval)
+	}
+
+	if op.Status == OperationFailed {
+		return op, &OperationError{OperationID: op.ID, Reason: op.Error}
+	}
+
+	return op, nil
+}
+
+// acceptedOperation returns the operation described by a 202 Accepted
+// response. Its status is polled from the URL in the Location header,
+// resolved against the endpoint, or from operations/{id} when absent.
+func (c *Client) acceptedOperation(resp *http.Response) (*Operation, error) {
+	var op Operation
+
+	if err := json.NewDecoder(resp.Body).Decode(&op); err != nil {
+		return nil, fmt.Errorf("decoding operation: %w", err)
+	}
+
+	location := resp.Header.Get("Location")
+
+	if location == "" {
+		location = "operations/" + url.PathEscape(op.ID)
+	}
+
+	u, err := c.baseURL.Parse(location)
+
+	if err != nil {
+		return nil, fmt.Errorf("parsing operation location %q: %w", location, err)
+	}
+
+	op.location = u.String()
+
+	return &op, nil
+}
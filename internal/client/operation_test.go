@@ -0,0 +1,129 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client/clienttest"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client/mockapi"
+)
+
+func TestClient_AsyncExampleLifecycle(t *testing.T) {
+	testCases := map[string]struct {
+		cacheReads bool
+	}{
+		"uncached": {},
+		"cached": {
+			cacheReads: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := clienttest.NewServer(t)
+			server.SetAsync(&mockapi.Async{Polls: 2})
+
+			c := newTestClient(t, client.Config{
+				Endpoint:        server.URL,
+				CacheReads:      testCase.cacheReads,
+				PollInterval:    time.Millisecond,
+				PollMaxInterval: 5 * time.Millisecond,
+			})
+			ctx := t.Context()
+
+			created, err := c.CreateExample(ctx, client.Example{Defaulted: "one"})
+
+			if err != nil {
+				t.Fatalf("unexpected create error: %s", err)
+			}
+
+			if created.ID != "example-1" || created.Defaulted != "one" {
+				t.Errorf("expected created example-1, got: %+v", created)
+			}
+
+			created.Defaulted = "two"
+
+			updated, err := c.UpdateExample(ctx, *created)
+
+			if err != nil {
+				t.Fatalf("unexpected update error: %s", err)
+			}
+
+			if updated.Defaulted != "two" {
+				t.Errorf("expected updated example, got: %+v", updated)
+			}
+
+			// The completed update is visible to subsequent reads.
+			got, err := c.GetExample(ctx, created.ID)
+
+			if err != nil {
+				t.Fatalf("unexpected read error: %s", err)
+			}
+
+			if got.Defaulted != "two" {
+				t.Errorf("expected updated example to be read, got: %+v", got)
+			}
+
+			if err := c.DeleteExample(ctx, created.ID); err != nil {
+				t.Fatalf("unexpected delete error: %s", err)
+			}
+
+			if _, ok := server.Example(created.ID); ok {
+				t.Error("expected example to be deleted")
+			}
+		})
+	}
+}
+
+func TestClient_WaitForOperation_Failed(t *testing.T) {
+	server := clienttest.NewServer(t)
+	server.SetAsync(&mockapi.Async{Polls: 1, Failure: "quota exceeded"})
+
+	c := newTestClient(t, client.Config{
+		Endpoint:        server.URL,
+		PollInterval:    time.Millisecond,
+		PollMaxInterval: 5 * time.Millisecond,
+	})
+
+	_, err := c.CreateExample(t.Context(), client.Example{})
+
+	var operationErr *client.OperationError
+
+	if !errors.As(err, &operationErr) {
+		t.Fatalf("expected operation error, got: %v", err)
+	}
+
+	if operationErr.Reason != "quota exceeded" {
+		t.Errorf("expected failure reason, got: %q", operationErr.Reason)
+	}
+
+	if _, ok := server.Example("example-1"); ok {
+		t.Error("expected failed operation not to create the example")
+	}
+}
+
+func TestClient_WaitForOperation_Timeout(t *testing.T) {
+	server := clienttest.NewServer(t)
+	server.SetAsync(&mockapi.Async{Polls: 1000})
+
+	c := newTestClient(t, client.Config{
+		Endpoint:        server.URL,
+		PollInterval:    time.Millisecond,
+		PollMaxInterval: 5 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.CreateExample(ctx, client.Example{})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitTransport limits the rate of requests with a token bucket and the
+// number of requests in flight with a semaphore. Both limits are shared by
+// every request sent through the transport, and waiting for either respects
+// request context cancellation.
+type rateLimitTransport struct {
+	base http.RoundTripper
+
+	// limiter is nil when the request rate is unlimited.
+	limiter *rate.Limiter
+
+	// semaphore is nil when the number of concurrent requests is unlimited.
+	semaphore chan struct{}
+}
+
+// newRateLimitTransport returns base wrapped with a rateLimitTransport, or
+// base itself when neither limit is set.
+func newRateLimitTransport(base http.RoundTripper, requestsPerSecond float64, maxConcurrentRequests int) http.RoundTripper {
+	if requestsPerSecond <= 0 && maxConcurrentRequests <= 0 {
+		return base
+	}
+
+	t := &rateLimitTransport{
+		base: base,
+	}
+
+	if requestsPerSecond > 0 {
+		// Allow a burst of up to one second of requests so that short spikes
+		// are not delayed unnecessarily.
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), max(1, int(math.Ceil(requestsPerSecond))))
+	}
+
+	if maxConcurrentRequests > 0 {
+		t.semaphore = make(chan struct{}, maxConcurrentRequests)
+	}
+
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.semaphore != nil {
+		select {
+		case t.semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			t.release()
+
+			return nil, err
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+
+	if err != nil {
+		t.release()
+
+		return nil, err
+	}
+
+	if t.semaphore != nil {
+		// The request remains in flight until its response body is closed.
+		resp.Body = &releaseOnCloseBody{ReadCloser: resp.Body, release: t.release}
+	}
+
+	return resp, nil
+}
+
+func (t *rateLimitTransport) release() {
+	if t.semaphore != nil {
+		<-t.semaphore
+	}
+}
+
+// releaseOnCloseBody calls release once when the body is closed.
+type releaseOnCloseBody struct {
+	io.ReadCloser
+
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+
+	b.once.Do(b.release)
+
+	return err
+}
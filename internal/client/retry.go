@@ -0,0 +1,189 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a failed request is retried
+	// when not configured.
+	DefaultMaxRetries = 3
+
+	// DefaultRetryMaxWait is the longest time waited between retries when not
+	// configured.
+	DefaultRetryMaxWait = 30 * time.Second
+
+	// retryMinWait is the wait before the first retry, which doubles with
+	// each subsequent attempt.
+	retryMinWait = 500 * time.Millisecond
+
+	// IdempotencyKeyHeader is the request header which allows the API to
+	// safely de-duplicate retried non-idempotent requests.
+	IdempotencyKeyHeader = "Idempotency-Key"
+)
+
+// retryTransport retries requests which fail with transient errors, waiting
+// with jittered exponential backoff between attempts.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	retryable := isRetryableRequest(req)
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			// Each attempt requires a fresh copy of the request body.
+			body, err := req.GetBody()
+
+			if err != nil {
+				return nil, err
+			}
+
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+
+		if !retryable || attempt >= t.maxRetries || ctx.Err() != nil || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt)
+
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				if retryAfter > t.maxWait {
+					// The API will not accept the request within the
+					// configured wait, so return its response as-is.
+					return resp, nil
+				}
+
+				wait = retryAfter
+			}
+
+			// Drain the body so the underlying connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		fields := map[string]any{
+			"attempt": attempt + 1,
+			"method":  req.Method,
+			"url":     req.URL.Redacted(),
+			"wait":    wait.String(),
+		}
+
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+		}
+
+		tflog.Debug(ctx, "Retrying API request", fields)
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the wait before the retry following attempt. The wait
+// doubles with each attempt up to the maximum wait, and a random jitter of up
+// to half the wait is subtracted so that concurrent clients spread out.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	wait := t.maxWait
+
+	if attempt < 32 && retryMinWait<<attempt < t.maxWait {
+		wait = retryMinWait << attempt
+	}
+
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// isRetryableRequest reports whether the request can be safely sent more
+// than once. POST requests are only retried when they carry an idempotency
+// key.
+func isRetryableRequest(req *http.Request) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return req.Header.Get(IdempotencyKeyHeader) != ""
+	default:
+		return false
+	}
+}
+
+// shouldRetry reports whether a response or error is transient. Only network
+// errors, such as timeouts and connection resets, are retried, so that
+// certificate and malformed request errors are returned immediately.
+//
+// Conditional requests without an idempotency key are not retried once the
+// API has responded, as it may have applied the request before failing and
+// the retry would then fail its precondition.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		var netErr net.Error
+
+		return errors.As(err, &netErr) ||
+			errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, io.ErrUnexpectedEOF)
+	}
+
+	if req.Header.Get("If-Match") != "" && req.Header.Get(IdempotencyKeyHeader) == "" {
+		return false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses a Retry-After header value in either delay-seconds
+// or HTTP-date form.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
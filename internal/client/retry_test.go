@@ -0,0 +1,340 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+)
+
+// newFlakyServer returns a server which responds with failStatus to the
+// first failures requests and with 200 OK afterwards, and a counter of the
+// requests it received.
+func newFlakyServer(t *testing.T, failures int32, failStatus int, retryAfter string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+
+			w.WriteHeader(failStatus)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"example-1"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestClient_Retry(t *testing.T) {
+	testCases := map[string]struct {
+		failures         int32
+		failStatus       int
+		retryAfter       string
+		maxRetries       int
+		call             func(context.Context, *client.Client) error
+		expectedRequests int32
+		expectedStatus   int
+	}{
+		"get-transient": {
+			failures:         2,
+			failStatus:       http.StatusServiceUnavailable,
+			retryAfter:       "0",
+			maxRetries:       3,
+			call:             getExample,
+			expectedRequests: 3,
+		},
+		"get-exhausted": {
+			failures:         5,
+			failStatus:       http.StatusTooManyRequests,
+			retryAfter:       "0",
+			maxRetries:       2,
+			call:             getExample,
+			expectedRequests: 3,
+			expectedStatus:   http.StatusTooManyRequests,
+		},
+		"get-not-transient": {
+			failures:         1,
+			failStatus:       http.StatusInternalServerError,
+			maxRetries:       3,
+			call:             getExample,
+			expectedRequests: 1,
+			expectedStatus:   http.StatusInternalServerError,
+		},
+		"get-retry-after-exceeds-max-wait": {
+			failures:         1,
+			failStatus:       http.StatusServiceUnavailable,
+			retryAfter:       "3600",
+			maxRetries:       3,
+			call:             getExample,
+			expectedRequests: 1,
+			expectedStatus:   http.StatusServiceUnavailable,
+		},
+		"post-with-idempotency-key": {
+			failures:   1,
+			failStatus: http.StatusBadGateway,
+			retryAfter: "0",
+			maxRetries: 3,
+			call: func(ctx context.Context, c *client.Client) error {
+				_, err := c.CreateExample(ctx, client.Example{})
+
+				return err
+			},
+			expectedRequests: 2,
+		},
+		"post-without-idempotency-key": {
+			failures:   1,
+			failStatus: http.StatusBadGateway,
+			retryAfter: "0",
+			maxRetries: 3,
+			call: func(ctx context.Context, c *client.Client) error {
+				return c.InvokeExampleAction(ctx, client.ExampleActionRequest{})
+			},
+			expectedRequests: 1,
+			expectedStatus:   http.StatusBadGateway,
+		},
+		"put-unconditional": {
+			failures:   1,
+			failStatus: http.StatusServiceUnavailable,
+			retryAfter: "0",
+			maxRetries: 3,
+			call: func(ctx context.Context, c *client.Client) error {
+				_, err := c.UpdateExample(ctx, client.Example{ID: "example-1"})
+
+				return err
+			},
+			expectedRequests: 2,
+		},
+		"put-conditional": {
+			failures:   1,
+			failStatus: http.StatusServiceUnavailable,
+			retryAfter: "0",
+			maxRetries: 3,
+			call: func(ctx context.Context, c *client.Client) error {
+				_, err := c.UpdateExample(ctx, client.Example{ID: "example-1", ETag: `"1"`})
+
+				return err
+			},
+			expectedRequests: 1,
+			expectedStatus:   http.StatusServiceUnavailable,
+		},
+		"disabled": {
+			failures:         1,
+			failStatus:       http.StatusServiceUnavailable,
+			retryAfter:       "0",
+			call:             getExample,
+			expectedRequests: 1,
+			expectedStatus:   http.StatusServiceUnavailable,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server, requests := newFlakyServer(t, testCase.failures, testCase.failStatus, testCase.retryAfter)
+
+			c, err := client.New(client.Config{
+				Endpoint:     server.URL,
+				MaxRetries:   testCase.maxRetries,
+				RetryMaxWait: time.Second,
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			err = testCase.call(t.Context(), c)
+
+			if got := requests.Load(); got != testCase.expectedRequests {
+				t.Errorf("expected %d requests, got %d", testCase.expectedRequests, got)
+			}
+
+			if testCase.expectedStatus == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			var apiErr *client.APIError
+
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != testCase.expectedStatus {
+				t.Fatalf("expected API error with status %d, got: %v", testCase.expectedStatus, err)
+			}
+		})
+	}
+}
+
+func TestClient_RetryTransportError(t *testing.T) {
+	testCases := map[string]struct {
+		tls                 bool
+		reset               bool
+		expectedConnections int32
+	}{
+		// The first connection is reset before a response is written.
+		"connection-reset": {
+			reset:               true,
+			expectedConnections: 2,
+		},
+		// The client does not trust the server certificate.
+		"certificate": {
+			tls:                 true,
+			expectedConnections: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var connections atomic.Int32
+
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if testCase.reset && connections.Load() == 1 {
+					conn, _, err := http.NewResponseController(w).Hijack()
+
+					if err != nil {
+						t.Errorf("unexpected error: %s", err)
+
+						return
+					}
+
+					_ = conn.(*net.TCPConn).SetLinger(0)
+					conn.Close()
+
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id":"example-1"}`))
+			}))
+			server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					connections.Add(1)
+				}
+			}
+			server.Config.ErrorLog = log.New(io.Discard, "", 0)
+
+			if testCase.tls {
+				server.StartTLS()
+			} else {
+				server.Start()
+			}
+
+			t.Cleanup(server.Close)
+
+			c, err := client.New(client.Config{
+				Endpoint:     server.URL,
+				MaxRetries:   3,
+				RetryMaxWait: time.Millisecond,
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			_, err = c.GetExample(t.Context(), "example-1")
+
+			if testCase.tls && err == nil {
+				t.Error("expected certificate error, got none")
+			}
+
+			if !testCase.tls && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+
+			if got := connections.Load(); got != testCase.expectedConnections {
+				t.Errorf("expected %d connections, got %d", testCase.expectedConnections, got)
+			}
+		})
+	}
+}
+
+func TestClient_RetryIdempotencyKey(t *testing.T) {
+	var keys []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(client.IdempotencyKeyHeader))
+
+		if len(keys) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"example-1"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.New(client.Config{Endpoint: server.URL, MaxRetries: 1})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := c.CreateExample(t.Context(), client.Example{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("expected the same idempotency key on both attempts, got: %q", keys)
+	}
+}
+
+func TestClient_RetryContextCanceled(t *testing.T) {
+	server, requests := newFlakyServer(t, 100, http.StatusServiceUnavailable, "")
+
+	c, err := client.New(client.Config{
+		Endpoint:     server.URL,
+		MaxRetries:   10,
+		RetryMaxWait: time.Minute,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	_, err = c.GetExample(ctx, "example-1")
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context deadline exceeded, got: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected request to stop waiting when canceled, took %s", elapsed)
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
+
+func getExample(ctx context.Context, c *client.Client) error {
+	_, err := c.GetExample(ctx, "example-1")
+
+	return err
+}
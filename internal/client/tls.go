@@ -0,0 +1,88 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// TLSConfig configures TLS for connections to the API and, when OAuth 2.0
+// authentication is used, to the token endpoint.
+type TLSConfig struct {
+	// CACertPEM contains PEM-encoded CA certificates trusted in addition to
+	// the system certificate pool.
+	CACertPEM string
+
+	// ClientCertPEM and ClientKeyPEM contain the PEM-encoded certificate and
+	// private key presented for mutual TLS authentication.
+	ClientCertPEM string
+	ClientKeyPEM  string
+
+	// InsecureSkipVerify disables verification of the server certificate
+	// chain and host name. It should only be used for testing.
+	InsecureSkipVerify bool
+}
+
+// tlsClientConfig returns the crypto/tls configuration described by c.
+func (c TLSConfig) tlsClientConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM([]byte(c.CACertPEM)) {
+			return nil, errors.New("no valid PEM-encoded certificates found in CA certificate")
+		}
+
+		config.RootCAs = pool
+	}
+
+	if c.ClientCertPEM != "" || c.ClientKeyPEM != "" {
+		if c.ClientCertPEM == "" || c.ClientKeyPEM == "" {
+			return nil, errors.New("client certificate and client key must be configured together")
+		}
+
+		cert, err := tls.X509KeyPair([]byte(c.ClientCertPEM), []byte(c.ClientKeyPEM))
+
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// tlsTransport returns a copy of base using the TLS configuration described
+// by c. The base transport must be an *http.Transport.
+func (c TLSConfig) tlsTransport(base http.RoundTripper) (http.RoundTripper, error) {
+	httpTransport, ok := base.(*http.Transport)
+
+	if !ok {
+		return nil, fmt.Errorf("TLS configuration requires an *http.Transport, got: %T", base)
+	}
+
+	tlsClientConfig, err := c.tlsClientConfig()
+
+	if err != nil {
+		return nil, err
+	}
+
+	httpTransport = httpTransport.Clone()
+	httpTransport.TLSClientConfig = tlsClientConfig
+
+	return httpTransport, nil
+}
@@ -0,0 +1,176 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client/clienttest"
+)
+
+func TestClient_TLS(t *testing.T) {
+	server := clienttest.NewTLSServer(t)
+
+	testCases := map[string]struct {
+		tls         *client.TLSConfig
+		expectError bool
+	}{
+		"untrusted": {
+			expectError: true,
+		},
+		"ca-cert": {
+			tls: &client.TLSConfig{CACertPEM: server.CACertPEM()},
+		},
+		"insecure-skip-verify": {
+			tls: &client.TLSConfig{InsecureSkipVerify: true},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c, err := client.New(client.Config{
+				Endpoint: server.URL,
+				TLS:      testCase.tls,
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			_, err = c.ListExamples(t.Context(), nil)
+
+			if testCase.expectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.expectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestClient_TLSClientCertificate(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(certPEM)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	serverCAPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	withoutCert, err := client.New(client.Config{
+		Endpoint: server.URL,
+		TLS:      &client.TLSConfig{CACertPEM: serverCAPEM},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := withoutCert.DeleteExample(t.Context(), "example-1"); err == nil {
+		t.Error("expected error without client certificate, got none")
+	}
+
+	withCert, err := client.New(client.Config{
+		Endpoint: server.URL,
+		TLS: &client.TLSConfig{
+			CACertPEM:     serverCAPEM,
+			ClientCertPEM: string(certPEM),
+			ClientKeyPEM:  string(keyPEM),
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := withCert.DeleteExample(t.Context(), "example-1"); err != nil {
+		t.Errorf("unexpected error with client certificate: %s", err)
+	}
+}
+
+func TestNew_TLSInvalid(t *testing.T) {
+	certPEM, _ := generateCertificate(t)
+
+	testCases := map[string]client.TLSConfig{
+		"ca-cert-not-pem": {
+			CACertPEM: "not a certificate",
+		},
+		"client-cert-without-key": {
+			ClientCertPEM: string(certPEM),
+		},
+		"client-key-mismatch": {
+			ClientCertPEM: string(certPEM),
+			ClientKeyPEM:  "not a key",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := client.New(client.Config{TLS: &testCase}); err == nil {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
+
+// generateCertificate returns a PEM-encoded self-signed client certificate
+// and its private key.
+func generateCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+
+	if err != nil {
+		t.Fatalf("unexpected error creating certificate: %s", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+
+	if err != nil {
+		t.Fatalf("unexpected error encoding key: %s", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
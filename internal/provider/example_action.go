@@ -6,12 +6,12 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/action/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -24,7 +24,7 @@ func NewExampleAction() action.Action {
 
 // ExampleAction defines the action implementation.
 type ExampleAction struct {
-	client *http.Client
+	client *client.Client
 }
 
 // ExampleActionModel describes the action data model.
@@ -56,18 +56,18 @@ func (e *ExampleAction) Configure(ctx context.Context, req action.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	apiClient, ok := req.ProviderData.(*client.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	e.client = client
+	e.client = apiClient
 }
 
 func (e *ExampleAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
@@ -85,13 +85,14 @@ func (e *ExampleAction) Invoke(ctx context.Context, req action.InvokeRequest, re
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := d.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
+	err := e.client.InvokeExampleAction(ctx, client.ExampleActionRequest{
+		ConfigurableAttribute: data.ConfigurableAttribute.ValueStringPointer(),
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to invoke example action, got error: %s", err))
+		return
+	}
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -8,9 +8,12 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client/clienttest"
 )
 
 func TestAccExampleAction(t *testing.T) {
+	server := clienttest.NewServer(t)
+
 	resource.Test(t, resource.TestCase{
 		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
 			tfversion.SkipBelow(tfversion.Version1_14_0),
@@ -19,31 +22,31 @@ func TestAccExampleAction(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccExampleActionConfig,
+				Config: testAccExampleActionConfig(server.URL),
 				PostApplyFunc: func() {
 					// Test the results of an action operation.
 					// Actions should not affect existing resources managed
 					// by Terraform, so testing should be scoped to real-world side effects,
 					// rather than Terraform plan or state values.
 					//
-					// For example, an action that changes the contents of a local file
-					// could test the contents of that file:
+					// This example action records each invocation with the API.
+					actions := server.Actions()
 
-					// resultContent, err := os.ReadFile(f)
-					// if err != nil {
-					//	 t.Errorf("Error occurred while reading file at path: %s\n, error: %s\n", f, err)
-					// }
-					//
-					// if string(resultContent) != updatedContent {
-					//	 t.Errorf("Expected file content %s\n, got: %s\n", updatedContent, resultContent)
-					// }
+					if len(actions) != 1 {
+						t.Fatalf("Expected 1 action invocation, got: %d", len(actions))
+					}
+
+					if got := actions[0].ConfigurableAttribute; got == nil || *got != "example" {
+						t.Errorf("Expected configurable_attribute %q, got: %v", "example", got)
+					}
 				},
 			},
 		},
 	})
 }
 
-const testAccExampleActionConfig = `
+func testAccExampleActionConfig(endpoint string) string {
+	return testAccProviderConfig(endpoint) + `
 resource "terraform_data" "test" {
 	input = "fake-string"
 
@@ -60,3 +63,4 @@ action "scaffolding_example" "test" {
 		configurable_attribute = "example"
 	}
 }`
+}
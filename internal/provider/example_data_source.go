@@ -6,12 +6,13 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -23,7 +24,8 @@ func NewExampleDataSource() datasource.DataSource {
 
 // ExampleDataSource defines the data source implementation.
 type ExampleDataSource struct {
-	client *http.Client
+	client      *client.Client
+	readTimeout time.Duration
 }
 
 // ExampleDataSourceModel describes the data source data model.
@@ -60,21 +62,48 @@ func (d *ExampleDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	providerData, ok := req.ProviderData.(*dataSourceProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.dataSourceProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.client
+	d.readTimeout = providerData.readTimeout
 }
 
 func (d *ExampleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, diags := withProviderMeta(ctx, req.ProviderMeta)
+
+	resp.Diagnostics.Append(diags...)
+
+	// The client is unset when the provider configuration is unknown, so the
+	// read is deferred until the configuration is known.
+	if d.client == nil {
+		if req.ClientCapabilities.DeferralAllowed {
+			resp.Deferred = &datasource.Deferred{
+				Reason: datasource.DeferredReasonProviderConfigUnknown,
+			}
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The data source cannot be read before the provider is configured. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, "read", d.readTimeout)
+	defer cancel()
+
 	var data ExampleDataSourceModel
 
 	// Read Terraform configuration data into the model
@@ -84,17 +113,22 @@ func (d *ExampleDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := d.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
-
-	// For the purposes of this example code, hardcoding a response value to
-	// save into the Terraform state.
-	data.Id = types.StringValue("example-id")
+	examples, err := d.client.ListExamples(ctx, data.ConfigurableAttribute.ValueStringPointer())
+
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "read example", err)
+		return
+	}
+
+	if len(examples) != 1 {
+		resp.Diagnostics.AddError(
+			"Unexpected Number of Examples",
+			fmt.Sprintf("Expected exactly one example matching the configuration, got: %d. Refine the configurable_attribute filter.", len(examples)),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(examples[0].ID)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
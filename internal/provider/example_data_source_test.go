@@ -4,22 +4,35 @@
 package provider
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client/clienttest"
 )
 
 func TestAccExampleDataSource(t *testing.T) {
+	server := clienttest.NewServer(t)
+	configurableAttribute := "example"
+
+	// Arrange an existing object for the data source to read.
+	server.PutExample(client.Example{
+		ID:                    "example-id",
+		ConfigurableAttribute: &configurableAttribute,
+		Defaulted:             "example value when not configured",
+	})
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Read testing
 			{
-				Config: testAccExampleDataSourceConfig,
+				Config: testAccExampleDataSourceConfig(server.URL, configurableAttribute),
 				ConfigStateChecks: []statecheck.StateCheck{
 					statecheck.ExpectKnownValue(
 						"data.scaffolding_example.test",
@@ -32,8 +45,10 @@ func TestAccExampleDataSource(t *testing.T) {
 	})
 }
 
-const testAccExampleDataSourceConfig = `
+func testAccExampleDataSourceConfig(endpoint string, configurableAttribute string) string {
+	return testAccProviderConfig(endpoint) + fmt.Sprintf(`
 data "scaffolding_example" "test" {
-  configurable_attribute = "example"
+  configurable_attribute = %[1]q
+}
+`, configurableAttribute)
 }
-`
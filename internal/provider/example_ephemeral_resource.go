@@ -5,14 +5,17 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ ephemeral.EphemeralResource = &ExampleEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ExampleEphemeralResource{}
 
 func NewExampleEphemeralResource() ephemeral.EphemeralResource {
 	return &ExampleEphemeralResource{}
@@ -20,7 +23,7 @@ func NewExampleEphemeralResource() ephemeral.EphemeralResource {
 
 // ExampleEphemeralResource defines the ephemeral resource implementation.
 type ExampleEphemeralResource struct {
-	// client *http.Client // If applicable, a client can be initialized here.
+	client *client.Client
 }
 
 // ExampleEphemeralResourceModel describes the ephemeral resource data model.
@@ -44,15 +47,54 @@ func (r *ExampleEphemeralResource) Schema(ctx context.Context, _ ephemeral.Schem
 				Required:            true, // Ephemeral resources expect their dependencies to already exist.
 			},
 			"value": schema.StringAttribute{
-				Computed: true,
-				// Sensitive:           true, // If applicable, mark the attribute as sensitive.
-				MarkdownDescription: "Example value",
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Example token issued by the API",
 			},
 		},
 	}
 }
 
+func (r *ExampleEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	apiClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = apiClient
+}
+
 func (r *ExampleEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	// The client is unset when the provider configuration is unknown, so the
+	// token is issued once the configuration is known.
+	if r.client == nil {
+		if req.ClientCapabilities.DeferralAllowed {
+			resp.Deferred = &ephemeral.Deferred{
+				Reason: ephemeral.DeferredReasonProviderConfigUnknown,
+			}
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The ephemeral resource cannot be opened before the provider is configured. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
 	var data ExampleEphemeralResourceModel
 
 	// Read Terraform config data into the model
@@ -61,16 +103,16 @@ func (r *ExampleEphemeralResource) Open(ctx context.Context, req ephemeral.OpenR
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
-	//
-	// However, this example hardcodes setting the token attribute to a specific value for brevity.
-	data.Value = types.StringValue("token-123")
+	token, err := r.client.IssueExampleToken(ctx, client.ExampleTokenRequest{
+		ConfigurableAttribute: data.ConfigurableAttribute.ValueString(),
+	})
+
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "issue example token", err)
+		return
+	}
+
+	data.Value = types.StringValue(token.Value)
 
 	// Save data into ephemeral result data
 	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
@@ -5,8 +5,13 @@ package provider
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
@@ -24,12 +29,12 @@ func TestAccExampleEphemeralResource(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccExampleEphemeralResourceConfig("example"),
+				Config: testAccMockProviderConfig(filepath.Join(t.TempDir(), "mock.json")) + testAccExampleEphemeralResourceConfig("example"),
 				ConfigStateChecks: []statecheck.StateCheck{
 					statecheck.ExpectKnownValue(
 						"echo.test",
 						tfjsonpath.New("data").AtMapKey("value"),
-						knownvalue.StringExact("token-123"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^token-`)),
 					),
 				},
 			},
@@ -50,3 +55,49 @@ provider "echo" {
 resource "echo" "test" {}
 `, configurableAttribute)
 }
+
+func TestExampleEphemeralResource_OpenUnconfigured(t *testing.T) {
+	r := &ExampleEphemeralResource{}
+
+	var schemaResp ephemeral.SchemaResponse
+
+	r.Schema(t.Context(), ephemeral.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(t.Context()), map[string]tftypes.Value{
+			"configurable_attribute": tftypes.NewValue(tftypes.String, "one"),
+			"value":                  tftypes.NewValue(tftypes.String, nil),
+		}),
+	}
+
+	t.Run("deferral-allowed", func(t *testing.T) {
+		req := ephemeral.OpenRequest{
+			Config: config,
+			ClientCapabilities: ephemeral.OpenClientCapabilities{
+				DeferralAllowed: true,
+			},
+		}
+		resp := &ephemeral.OpenResponse{}
+
+		r.Open(t.Context(), req, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		if resp.Deferred == nil || resp.Deferred.Reason != ephemeral.DeferredReasonProviderConfigUnknown {
+			t.Errorf("expected deferred response, got: %v", resp.Deferred)
+		}
+	})
+
+	t.Run("deferral-not-allowed", func(t *testing.T) {
+		resp := &ephemeral.OpenResponse{}
+
+		r.Open(t.Context(), ephemeral.OpenRequest{Config: config}, resp)
+
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Unconfigured Provider" {
+			t.Errorf("expected unconfigured provider error, got: %v", resp.Diagnostics)
+		}
+	})
+}
@@ -5,22 +5,35 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ExampleResource{}
 var _ resource.ResourceWithImportState = &ExampleResource{}
+var _ resource.ResourceWithModifyPlan = &ExampleResource{}
+var _ resource.ResourceWithIdentity = &ExampleResource{}
+var _ resource.ResourceWithUpgradeState = &ExampleResource{}
 
 func NewExampleResource() resource.Resource {
 	return &ExampleResource{}
@@ -28,18 +41,49 @@ func NewExampleResource() resource.Resource {
 
 // ExampleResource defines the resource implementation.
 type ExampleResource struct {
-	client *http.Client
+	client   *client.Client
+	tags     tagPolicy
+	timeouts operationTimeouts
 }
 
 // ExampleResourceModel describes the resource data model.
 type ExampleResourceModel struct {
-	ConfigurableAttribute types.String `tfsdk:"configurable_attribute"`
-	Defaulted             types.String `tfsdk:"defaulted"`
-	Id                    types.String `tfsdk:"id"`
+	ConfigurableAttribute types.String   `tfsdk:"configurable_attribute"`
+	Defaulted             types.String   `tfsdk:"defaulted"`
+	DefaultedAttribute    types.String   `tfsdk:"defaulted_attribute"`
+	Id                    types.String   `tfsdk:"id"`
+	Project               types.String   `tfsdk:"project"`
+	Region                types.String   `tfsdk:"region"`
+	Revision              types.Int64    `tfsdk:"revision"`
+	Tags                  types.Map      `tfsdk:"tags"`
+	TagsAll               types.Map      `tfsdk:"tags_all"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+// ExampleResourceIdentityModel describes the resource identity data model.
+type ExampleResourceIdentityModel struct {
+	Id      types.String `tfsdk:"id"`
+	Project types.String `tfsdk:"project"`
+	Region  types.String `tfsdk:"region"`
+}
+
+// exampleImmutableAttributes are the attributes which the API cannot update,
+// so that changing them replaces the example.
+var exampleImmutableAttributes = []string{"project", "region"}
+
+// exampleImportIDFormats are the accepted formats of example import
+// identifiers, either the short or the fully-qualified form.
+var exampleImportIDFormats = []importIDFormat{
+	{"id"},
+	{"project", "region", "id"},
 }
 
 func (r *ExampleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_example"
+
+	// Call ModifyPlan even when the provider defers its configuration, so
+	// that plans remain accurate while the provider configuration is unknown.
+	resp.ResourceBehavior.ProviderDeferred.EnablePlanModification = true
 }
 
 func (r *ExampleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -47,17 +91,75 @@ func (r *ExampleResource) Schema(ctx context.Context, req resource.SchemaRequest
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Example resource",
 
+		// Increment the version when changing the schema in a way which
+		// existing state cannot be read with, and add a state upgrader from
+		// the prior version to UpgradeState.
+		Version: 1,
+
 		Attributes: map[string]schema.Attribute{
 			"configurable_attribute": schema.StringAttribute{
 				MarkdownDescription: "Example configurable attribute",
 				Optional:            true,
 			},
 			"defaulted": schema.StringAttribute{
+				MarkdownDescription: "Deprecated alias of `defaulted_attribute`, which it conflicts with.",
+				DeprecationMessage:  "Use defaulted_attribute instead. The defaulted attribute will be removed in the next major version.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("defaulted_attribute")),
+				},
+			},
+			"defaulted_attribute": schema.StringAttribute{
 				MarkdownDescription: "Example configurable attribute with default value",
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString("example value when not configured"),
 			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the example belongs to. Defaults to the default project of the API server. Changing this forces a new example to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region the example is located in. Defaults to the default region of the API server. Changing this forces a new example to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"revision": schema.Int64Attribute{
+				MarkdownDescription: "Revision of the example, incremented by every update.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "Map of tags to apply to the example. Tags override provider `default_tags` with the same key. Requires an API server which supports tags, otherwise provider `default_tags` are not applied.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"tags_all": schema.MapAttribute{
+				MarkdownDescription: "Map of all tags applied to the example, including provider `default_tags` and excluding provider `ignore_tags`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"timeouts": timeoutsAttribute(ctx, timeouts.Opts{
+				Create:            true,
+				Read:              true,
+				Update:            true,
+				Delete:            true,
+				CreateDescription: "Timeout for creating the example, such as `30m`. Defaults to the provider `default_timeouts` create timeout.",
+				ReadDescription:   "Timeout for reading the example, such as `5m`. Defaults to the provider `default_timeouts` read timeout.",
+				UpdateDescription: "Timeout for updating the example, such as `30m`. Defaults to the provider `default_timeouts` update timeout.",
+				DeleteDescription: "Timeout for deleting the example, such as `30m`. Defaults to the provider `default_timeouts` delete timeout. " +
+					"Only applies once the timeout has been saved to state by an earlier apply.",
+			}),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Example identifier",
@@ -69,47 +171,89 @@ func (r *ExampleResource) Schema(ctx context.Context, req resource.SchemaRequest
 	}
 }
 
+func (r *ExampleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "Example identifier",
+				RequiredForImport: true,
+			},
+			"project": identityschema.StringAttribute{
+				Description:       "Project the example belongs to. Defaults to the example's project when imported.",
+				OptionalForImport: true,
+			},
+			"region": identityschema.StringAttribute{
+				Description:       "Region the example is located in. Defaults to the example's region when imported.",
+				OptionalForImport: true,
+			},
+		},
+	}
+}
+
+func (r *ExampleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := exampleResourceSchemaV0(ctx)
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &schemaV0,
+			StateUpgrader: upgradeExampleResourceStateV0,
+		},
+	}
+}
+
 func (r *ExampleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	providerData, ok := req.ProviderData.(*resourceProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.resourceProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.client
+	r.tags = providerData.tags
+	r.timeouts = providerData.timeouts
 }
 
 func (r *ExampleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, diags := withProviderMeta(ctx, req.ProviderMeta)
+
+	resp.Diagnostics.Append(diags...)
+
 	var data ExampleResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
+	createTimeout, diags := data.Timeouts.Create(ctx, r.timeouts.create)
+
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create example, got error: %s", err))
-	//     return
-	// }
+	ctx, cancel := withOperationTimeout(ctx, "create", createTimeout)
+	defer cancel()
+
+	example, err := r.client.CreateExample(ctx, data.toAPI())
+
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "create example", err)
+		return
+	}
+
+	data.fromAPI(example, r.tags)
 
-	// For the purposes of this example code, hardcoding a response value to
-	// save into the Terraform state.
-	data.Id = types.StringValue("example-id")
+	resp.Diagnostics.Append(setETag(ctx, resp.Private, example.ETag)...)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -117,71 +261,464 @@ func (r *ExampleResource) Create(ctx context.Context, req resource.CreateRequest
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *ExampleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, diags := withProviderMeta(ctx, req.ProviderMeta)
+
+	resp.Diagnostics.Append(diags...)
+
 	var data ExampleResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
+	readTimeout, diags := data.Timeouts.Read(ctx, r.timeouts.read)
+
+	resp.Diagnostics.Append(diags...)
+
+	imported, diags := getImported(ctx, req.Private)
+
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
+	ctx, cancel := withOperationTimeout(ctx, "read", readTimeout)
+	defer cancel()
+
+	example, err := r.client.GetExample(ctx, data.Id.ValueString())
+
+	// The example was deleted outside of Terraform, so remove it from state
+	// to plan its re-creation. When importing, an example in another project
+	// or region is treated the same, as the identifier was imported with the
+	// wrong location.
+	if client.IsNotFound(err) || (err == nil && imported && !data.locatedAt(example)) {
+		tflog.Warn(ctx, "Example not found, removing from state", map[string]any{
+			"id":      data.Id.ValueString(),
+			"project": data.Project.ValueString(),
+			"region":  data.Region.ValueString(),
+		})
+
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "read example", err)
+		return
+	}
+
+	// Examples cannot move, so a managed example reported in another location
+	// is not removed from state, which would plan a duplicate.
+	if !data.locatedAt(example) {
+		resp.Diagnostics.AddError(
+			"Unexpected Example Location",
+			fmt.Sprintf("The API reported example %s in project %q and region %q, but it is managed in project %q and region %q. ",
+				example.ID, example.Project, example.Region, data.Project.ValueString(), data.Region.ValueString())+
+				"Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	data.fromAPI(example, r.tags)
+
+	resp.Diagnostics.Append(setETag(ctx, resp.Private, example.ETag)...)
+	resp.Diagnostics.Append(setImported(ctx, resp.Private, false)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *ExampleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, diags := withProviderMeta(ctx, req.ProviderMeta)
+
+	resp.Diagnostics.Append(diags...)
+
 	var data ExampleResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, r.timeouts.update)
+
+	resp.Diagnostics.Append(diags...)
+
+	// Only update the example if it is unchanged since it was last read, so
+	// that changes made outside of Terraform are not overwritten.
+	etag, diags := getETag(ctx, req.Private)
+
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update example, got error: %s", err))
-	//     return
-	// }
+	ctx, cancel := withOperationTimeout(ctx, "update", updateTimeout)
+	defer cancel()
+
+	update := data.toAPI()
+	update.ETag = etag
+
+	// Updates replace all tags, so carry over the ignored tags managed
+	// outside of Terraform.
+	if r.tags.hasIgnored() {
+		current, err := r.client.GetExample(ctx, data.Id.ValueString())
+
+		if err != nil {
+			addClientError(ctx, &resp.Diagnostics, "read example", err)
+			return
+		}
+
+		for key, value := range current.Tags {
+			if r.tags.ignored(key) {
+				if update.Tags == nil {
+					update.Tags = make(map[string]string)
+				}
+
+				update.Tags[key] = value
+			}
+		}
+	}
+
+	example, err := r.client.UpdateExample(ctx, update)
+
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "update example", err)
+		return
+	}
+
+	data.fromAPI(example, r.tags)
+
+	resp.Diagnostics.Append(setETag(ctx, resp.Private, example.ETag)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *ExampleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, diags := withProviderMeta(ctx, req.ProviderMeta)
+
+	resp.Diagnostics.Append(diags...)
+
 	var data ExampleResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, r.timeouts.delete)
+
+	resp.Diagnostics.Append(diags...)
+
+	// Only delete the example if it is unchanged since it was last read.
+	etag, diags := getETag(ctx, req.Private)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, "delete", deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteExampleIfMatch(ctx, data.Id.ValueString(), etag)
+
+	// The example is already gone, which is the desired outcome.
+	if client.IsNotFound(err) {
+		return
+	}
+
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "delete example", err)
+		return
+	}
+}
+
+func (r *ExampleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, diags := withProviderMeta(ctx, req.ProviderMeta)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Plan the deprecated alias regardless of the provider configuration, as
+	// it does not use the API.
+	if !req.Plan.Raw.IsNull() {
+		planDefaultedAlias(ctx, req.Config, &resp.Plan, &resp.Diagnostics)
+	}
+
+	// The client is unset when the provider configuration is unknown, so the
+	// change is deferred until the configuration is known.
+	if r.client == nil {
+		if req.ClientCapabilities.DeferralAllowed {
+			resp.Deferred = &resource.Deferred{
+				Reason: resource.DeferredReasonProviderConfigUnknown,
+			}
+		}
+
+		return
+	}
+
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var tags types.Map
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &tags)...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete example, got error: %s", err))
-	//     return
-	// }
+	// Plan tags_all from the resource and provider default tags, so that
+	// changes to either are shown as changes to the resource.
+	tagsAll := types.MapUnknown(types.StringType)
+
+	resourceTags, known := tagsFromValue(tags)
+
+	switch {
+	case !known:
+		// tags_all remains unknown until the tags are known.
+	case !r.client.Capabilities().Supports(client.FeatureExampleTags):
+		// Servers without tag support reject tags, so configured tags are an
+		// error while provider default tags are omitted.
+		if len(resourceTags) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tags"),
+				"Unsupported Attribute",
+				fmt.Sprintf("The API server (version %q) does not support tags on examples. ", r.client.Capabilities().APIVersion)+
+					"Remove the tags attribute, or use a server which supports tags.",
+			)
+
+			return
+		}
+
+		tagsAll = tagsValue(nil, tags)
+	default:
+		for key := range resourceTags {
+			if r.tags.ignored(key) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("tags").AtMapKey(key),
+					"Ignored Tag Configured",
+					fmt.Sprintf("The tag %q matches the provider ignore_tags configuration, so it cannot be managed by this resource. "+
+						"Remove the tag from the resource or from ignore_tags.", key),
+				)
+			}
+		}
+
+		tagsAll = tagsValue(r.tags.merge(resourceTags), tags)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+
+	// Nothing else to plan when the example is unchanged.
+	if resp.Diagnostics.HasError() || resp.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	replace := false
+
+	if !req.State.Raw.IsNull() {
+		for _, attribute := range exampleImmutableAttributes {
+			var planned, prior types.String
+
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(attribute), &planned)...)
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attribute), &prior)...)
+
+			if !planned.Equal(prior) {
+				resp.RequiresReplace.Append(path.Root(attribute))
+				replace = true
+			}
+		}
+
+		// Every update increments the revision, so it is only known after
+		// the update is applied.
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("revision"), types.Int64Unknown())...)
+	}
+
+	if resp.Diagnostics.HasError() || !r.client.Capabilities().Supports(client.FeatureExampleDryRun) {
+		return
+	}
+
+	r.validatePlan(ctx, req.Config, resp.Plan, replace, &resp.Diagnostics)
+}
+
+// planDefaultedAlias plans defaulted_attribute with the value of its
+// deprecated alias defaulted, when configured, and plans defaulted with the
+// value of defaulted_attribute, so that both are always in state.
+func planDefaultedAlias(ctx context.Context, config tfsdk.Config, plan *tfsdk.Plan, diags *diag.Diagnostics) {
+	var alias, defaulted types.String
+
+	diags.Append(config.GetAttribute(ctx, path.Root("defaulted"), &alias)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("defaulted_attribute"), &defaulted)...)
+
+	if diags.HasError() {
+		return
+	}
+
+	if !alias.IsNull() {
+		defaulted = alias
+	}
+
+	diags.Append(plan.SetAttribute(ctx, path.Root("defaulted_attribute"), defaulted)...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("defaulted"), defaulted)...)
+}
+
+// validatePlan asks the API to validate the planned example, so that errors
+// the API would return when applying the plan are reported while planning.
+// Examples being replaced are validated as created. Plans with unknown values
+// are not validated.
+func (r *ExampleResource) validatePlan(ctx context.Context, config tfsdk.Config, plan tfsdk.Plan, replace bool, diags *diag.Diagnostics) {
+	var configured, data ExampleResourceModel
+
+	diags.Append(config.Get(ctx, &configured)...)
+	diags.Append(plan.Get(ctx, &data)...)
+
+	if diags.HasError() {
+		return
+	}
+
+	// Unconfigured project and region are planned as unknown when created,
+	// and default to those of the API server.
+	for _, value := range []attr.Value{
+		configured.Project,
+		configured.Region,
+		data.ConfigurableAttribute,
+		data.DefaultedAttribute,
+		data.TagsAll,
+	} {
+		if value.IsUnknown() {
+			return
+		}
+	}
+
+	readTimeout, timeoutDiags := data.Timeouts.Read(ctx, r.timeouts.read)
+
+	diags.Append(timeoutDiags...)
+
+	if diags.HasError() {
+		return
+	}
+
+	ctx, cancel := withOperationTimeout(ctx, "read", readTimeout)
+	defer cancel()
+
+	example := data.toAPI()
+
+	if replace {
+		example.ID = ""
+	}
+
+	err := r.client.ValidateExample(ctx, example)
+
+	var apiErr *client.APIError
+
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+		diags.AddError(
+			"Invalid Example",
+			fmt.Sprintf("The API server rejected the planned example: %s", apiErr.Message),
+		)
+
+		return
+	}
+
+	if err != nil {
+		addClientError(ctx, diags, "validate example", err)
+	}
 }
 
 func (r *ExampleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// The client is unset when the provider configuration is unknown, so the
+	// import is deferred until the configuration is known.
+	if r.client == nil && req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &resource.Deferred{
+			Reason: resource.DeferredReasonProviderConfigUnknown,
+		}
+
+		return
+	}
+
+	// Mark the example as imported, so that Read removes it from state when
+	// it is not found at the imported location.
+	resp.Diagnostics.Append(setImported(ctx, resp.Private, true)...)
+
+	if req.ID != "" {
+		resp.Diagnostics.Append(importStateFromID(ctx, req.ID, &resp.State, exampleImportIDFormats...)...)
+
+		return
+	}
+
+	// Imports by identity also carry the project and region when configured.
+	var identity ExampleResourceIdentityModel
+
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.Id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), identity.Project)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("region"), identity.Region)...)
+}
+
+// toAPI converts the Terraform data model into an API request object.
+func (m ExampleResourceModel) toAPI() client.Example {
+	// tags_all is always known once planned.
+	tagsAll, _ := tagsFromValue(m.TagsAll)
+
+	return client.Example{
+		ID:                    m.Id.ValueString(),
+		Project:               m.Project.ValueString(),
+		Region:                m.Region.ValueString(),
+		ConfigurableAttribute: m.ConfigurableAttribute.ValueStringPointer(),
+		Defaulted:             m.DefaultedAttribute.ValueString(),
+		Tags:                  tagsAll,
+	}
+}
+
+// fromAPI updates the Terraform data model from an API response object,
+// attributing its tags to the resource or the provider tag policy.
+func (m *ExampleResourceModel) fromAPI(example *client.Example, policy tagPolicy) {
+	configuredTags, _ := tagsFromValue(m.Tags)
+
+	m.Id = types.StringValue(example.ID)
+	m.Project = types.StringValue(example.Project)
+	m.Region = types.StringValue(example.Region)
+	m.Revision = types.Int64Value(example.Revision)
+	m.ConfigurableAttribute = types.StringPointerValue(example.ConfigurableAttribute)
+	m.Defaulted = types.StringValue(example.Defaulted)
+	m.DefaultedAttribute = types.StringValue(example.Defaulted)
+	m.TagsAll = tagsValue(policy.withoutIgnored(example.Tags), m.Tags)
+	m.Tags = tagsValue(policy.resourceTags(example.Tags, configuredTags), m.Tags)
+}
+
+// identity returns the resource identity of the example described by the
+// Terraform data model.
+func (m ExampleResourceModel) identity() ExampleResourceIdentityModel {
+	return ExampleResourceIdentityModel{
+		Id:      m.Id,
+		Project: m.Project,
+		Region:  m.Region,
+	}
+}
+
+// locatedAt reports whether example is in the project and region of the
+// Terraform data model, where known.
+func (m ExampleResourceModel) locatedAt(example *client.Example) bool {
+	projectMatches := m.Project.IsNull() || m.Project.IsUnknown() || m.Project.ValueString() == example.Project
+	regionMatches := m.Region.IsNull() || m.Region.IsUnknown() || m.Region.ValueString() == example.Region
+
+	return projectMatches && regionMatches
 }
@@ -5,31 +5,42 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client/clienttest"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client/mockapi"
 )
 
 func TestAccExampleResource(t *testing.T) {
+	server := clienttest.NewServer(t)
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccExampleResourceConfig("one"),
+				Config: testAccExampleResourceConfig(server.URL, "one"),
 				ConfigStateChecks: []statecheck.StateCheck{
 					statecheck.ExpectKnownValue(
 						"scaffolding_example.test",
 						tfjsonpath.New("id"),
-						knownvalue.StringExact("example-id"),
+						knownvalue.StringExact("example-1"),
 					),
 					statecheck.ExpectKnownValue(
 						"scaffolding_example.test",
-						tfjsonpath.New("defaulted"),
+						tfjsonpath.New("defaulted_attribute"),
 						knownvalue.StringExact("example value when not configured"),
 					),
 					statecheck.ExpectKnownValue(
@@ -44,24 +55,19 @@ func TestAccExampleResource(t *testing.T) {
 				ResourceName:      "scaffolding_example.test",
 				ImportState:       true,
 				ImportStateVerify: true,
-				// This is not normally necessary, but is here because this
-				// example code does not have an actual upstream service.
-				// Once the Read method is able to refresh information from
-				// the upstream service, this can be removed.
-				ImportStateVerifyIgnore: []string{"configurable_attribute", "defaulted"},
 			},
 			// Update and Read testing
 			{
-				Config: testAccExampleResourceConfig("two"),
+				Config: testAccExampleResourceConfig(server.URL, "two"),
 				ConfigStateChecks: []statecheck.StateCheck{
 					statecheck.ExpectKnownValue(
 						"scaffolding_example.test",
 						tfjsonpath.New("id"),
-						knownvalue.StringExact("example-id"),
+						knownvalue.StringExact("example-1"),
 					),
 					statecheck.ExpectKnownValue(
 						"scaffolding_example.test",
-						tfjsonpath.New("defaulted"),
+						tfjsonpath.New("defaulted_attribute"),
 						knownvalue.StringExact("example value when not configured"),
 					),
 					statecheck.ExpectKnownValue(
@@ -76,10 +82,714 @@ func TestAccExampleResource(t *testing.T) {
 	})
 }
 
-func testAccExampleResourceConfig(configurableAttribute string) string {
+func TestAccExampleResource_Drift(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceConfig(server.URL, "one"),
+			},
+			// Changes made outside of Terraform are refreshed and reverted.
+			{
+				PreConfig: func() {
+					changed := "changed"
+
+					example, _ := server.Example("example-1")
+					example.ConfigurableAttribute = &changed
+					server.PutExample(example)
+				},
+				Config: testAccExampleResourceConfig(server.URL, "one"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("scaffolding_example.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("configurable_attribute"),
+						knownvalue.StringExact("one"),
+					),
+				},
+			},
+			// Examples deleted outside of Terraform are re-created.
+			{
+				PreConfig: func() {
+					server.DeleteExample("example-1")
+				},
+				Config: testAccExampleResourceConfig(server.URL, "one"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("scaffolding_example.test", plancheck.ResourceActionCreate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("example-2"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccExampleResource_Identity(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceProjectConfig(server.URL, "team"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity(
+						"scaffolding_example.test",
+						map[string]knownvalue.Check{
+							"id":      knownvalue.StringExact("example-1"),
+							"project": knownvalue.StringExact("team"),
+							"region":  knownvalue.StringExact(mockapi.DefaultRegion),
+						},
+					),
+					statecheck.ExpectIdentityValueMatchesState(
+						"scaffolding_example.test",
+						tfjsonpath.New("id"),
+					),
+					statecheck.ExpectIdentityValueMatchesState(
+						"scaffolding_example.test",
+						tfjsonpath.New("project"),
+					),
+				},
+			},
+			// Import with an import block using the resource identity.
+			{
+				ResourceName:    "scaffolding_example.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+			// Import with an import block using the identifier.
+			{
+				ResourceName:    "scaffolding_example.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Changing the project replaces the example.
+			{
+				Config: testAccExampleResourceProjectConfig(server.URL, "other"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("scaffolding_example.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity(
+						"scaffolding_example.test",
+						map[string]knownvalue.Check{
+							"id":      knownvalue.StringExact("example-2"),
+							"project": knownvalue.StringExact("other"),
+							"region":  knownvalue.StringExact(mockapi.DefaultRegion),
+						},
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccExampleResource_IdentityWrongProject(t *testing.T) {
+	server := clienttest.NewServer(t)
+	server.PutExample(client.Example{ID: "example-1", Project: "team", Region: mockapi.DefaultRegion})
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Examples are not found in projects they do not belong to.
+			{
+				Config: testAccProviderConfig(server.URL) + `
+import {
+  to = scaffolding_example.test
+  identity = {
+    id      = "example-1"
+    project = "other"
+  }
+}
+
+resource "scaffolding_example" "test" {
+  project = "other"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Cannot import non-existent remote object`),
+			},
+		},
+	})
+}
+
+func TestAccExampleResource_ImportID(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceProjectConfig(server.URL, "team"),
+			},
+			// Import with the short form of the identifier.
+			{
+				ResourceName:      "scaffolding_example.test",
+				ImportState:       true,
+				ImportStateId:     "example-1",
+				ImportStateVerify: true,
+			},
+			// Import with the fully-qualified form of the identifier.
+			{
+				ResourceName:      "scaffolding_example.test",
+				ImportState:       true,
+				ImportStateId:     "team/" + mockapi.DefaultRegion + "/example-1",
+				ImportStateVerify: true,
+			},
+			// Import into the wrong location finds no example.
+			{
+				ResourceName:  "scaffolding_example.test",
+				ImportState:   true,
+				ImportStateId: "other/" + mockapi.DefaultRegion + "/example-1",
+				ExpectError:   regexp.MustCompile(`Cannot import non-existent remote object`),
+			},
+			// Malformed identifiers show the expected formats.
+			{
+				ResourceName:  "scaffolding_example.test",
+				ImportState:   true,
+				ImportStateId: "team/example-1",
+				ExpectError:   regexp.MustCompile(`(?s)Invalid Import Identifier.*project/region/id`),
+			},
+		},
+	})
+}
+
+func TestAccExampleResource_DefaultedAlias(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The deprecated alias sets defaulted_attribute.
+			{
+				Config: testAccProviderConfig(server.URL) + `
+resource "scaffolding_example" "test" {
+  defaulted = "two"
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("defaulted"),
+						knownvalue.StringExact("two"),
+					),
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("defaulted_attribute"),
+						knownvalue.StringExact("two"),
+					),
+				},
+			},
+			// Replacing the alias with the attribute plans no changes.
+			{
+				Config: testAccProviderConfig(server.URL) + `
+resource "scaffolding_example" "test" {
+  defaulted_attribute = "two"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: testAccProviderConfig(server.URL) + `
+resource "scaffolding_example" "test" {
+  defaulted           = "two"
+  defaulted_attribute = "two"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccExampleResource_PlanModification(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceRegionConfig(server.URL, "one", mockapi.DefaultRegion),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("revision"),
+						knownvalue.Int64Exact(1),
+					),
+				},
+			},
+			// Updates plan the revision as unknown.
+			{
+				Config: testAccExampleResourceRegionConfig(server.URL, "two", mockapi.DefaultRegion),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("scaffolding_example.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("scaffolding_example.test", tfjsonpath.New("revision")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("revision"),
+						knownvalue.Int64Exact(2),
+					),
+				},
+			},
+			// Immutable attributes replace the example.
+			{
+				Config: testAccExampleResourceRegionConfig(server.URL, "two", "eu-west"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("scaffolding_example.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("example-2"),
+					),
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("revision"),
+						knownvalue.Int64Exact(1),
+					),
+				},
+			},
+			// Unchanged examples plan no changes.
+			{
+				Config: testAccExampleResourceRegionConfig(server.URL, "two", "eu-west"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccExampleResource_DryRun(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Servers supporting dry runs reject invalid examples while
+			// planning.
+			{
+				Config:      testAccExampleResourceRegionConfig(server.URL, "one", "moon"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?s)Invalid Example.*unknown region "moon"`),
+			},
+			// Other servers reject them when applying.
+			{
+				PreConfig: func() {
+					server.SetCapabilities(client.Capabilities{
+						APIVersion: "1.0",
+						Features:   []string{client.FeatureExampleTags},
+					})
+				},
+				Config:      testAccExampleResourceRegionConfig(server.URL, "one", "moon"),
+				ExpectError: regexp.MustCompile(`(?s)Unable to create example.*unknown region "moon"`),
+			},
+		},
+	})
+}
+
+func TestAccExampleResource_UnexpectedLocation(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceProjectConfig(server.URL, "team"),
+			},
+			// Managed examples reported in another location are not removed
+			// from state.
+			{
+				PreConfig: func() {
+					example, _ := server.Example("example-1")
+					example.Project = "other"
+					server.PutExample(example)
+				},
+				Config:      testAccExampleResourceProjectConfig(server.URL, "team"),
+				ExpectError: regexp.MustCompile(`Unexpected Example Location`),
+			},
+			// Restore the example so that it can be destroyed.
+			{
+				PreConfig: func() {
+					example, _ := server.Example("example-1")
+					example.Project = "team"
+					server.PutExample(example)
+				},
+				Config: testAccExampleResourceProjectConfig(server.URL, "team"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccExampleResource_DefaultTags(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceDefaultTagsConfig(server.URL, "platform"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("tags"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"name": knownvalue.StringExact("example"),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("tags_all"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"name":  knownvalue.StringExact("example"),
+							"owner": knownvalue.StringExact("platform"),
+						}),
+					),
+				},
+			},
+			// Changing only the default tags updates the resource.
+			{
+				Config: testAccExampleResourceDefaultTagsConfig(server.URL, "team-a"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("scaffolding_example.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("tags_all"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"name":  knownvalue.StringExact("example"),
+							"owner": knownvalue.StringExact("team-a"),
+						}),
+					),
+				},
+			},
+			// Ignored tags added outside of Terraform are not reported as
+			// changes.
+			{
+				PreConfig: func() {
+					example, _ := server.Example("example-1")
+					example.Tags = map[string]string{
+						"name":          "example",
+						"owner":         "team-a",
+						"external:user": "someone",
+					}
+					server.PutExample(example)
+				},
+				Config: testAccExampleResourceDefaultTagsConfig(server.URL, "team-a"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccExampleResource_TagsUnsupported(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	// Stand in for a server which predates tag support.
+	server.SetCapabilities(client.Capabilities{
+		APIVersion: "1.0",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Configured tags are rejected while planning.
+			{
+				Config:      testAccExampleResourceDefaultTagsConfig(server.URL, "platform"),
+				ExpectError: regexp.MustCompile(`Unsupported Attribute`),
+			},
+			// Provider default tags are omitted.
+			{
+				Config: fmt.Sprintf(`
+provider "scaffolding" {
+  endpoint = %[1]q
+
+  default_tags {
+    tags = {
+      owner = "platform"
+    }
+  }
+}
+
+resource "scaffolding_example" "test" {}
+`, server.URL),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("tags_all"),
+						knownvalue.Null(),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccExampleResource_Timeouts(t *testing.T) {
+	backend := mockapi.NewBackend()
+
+	var hanging atomic.Bool
+
+	hang := make(chan struct{})
+
+	// Stand in for a server which, while hanging, never completes creating
+	// examples, although it still validates them while planning.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hanging.Load() && r.Method == http.MethodPost && r.URL.Path == "/examples" && !r.URL.Query().Has("dry_run") {
+			<-hang
+		}
+
+		backend.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(hang) })
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExampleResourceTimeoutsConfig(server.URL, "-1m"),
+				ExpectError: regexp.MustCompile(`Invalid Duration`),
+			},
+			{
+				PreConfig: func() {
+					hanging.Store(true)
+				},
+				Config:      testAccExampleResourceTimeoutsConfig(server.URL, "1s"),
+				ExpectError: regexp.MustCompile(`create operation did not complete within its 1s timeout`),
+			},
+			{
+				PreConfig: func() {
+					hanging.Store(false)
+				},
+				Config: testAccExampleResourceTimeoutsConfig(server.URL, "30m"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("timeouts").AtMapKey("create"),
+						knownvalue.StringExact("30m"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccExampleResourceTimeoutsConfig(endpoint string, create string) string {
+	return testAccProviderConfig(endpoint) + fmt.Sprintf(`
+resource "scaffolding_example" "test" {
+  timeouts = {
+    create = %[1]q
+  }
+}
+`, create)
+}
+
+func TestAccExampleResource_Async(t *testing.T) {
+	server := clienttest.NewServer(t)
+	server.SetAsync(&mockapi.Async{Polls: 1})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceConfig(server.URL, "one"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("example-1"),
+					),
+				},
+			},
+			{
+				Config: testAccExampleResourceConfig(server.URL, "two"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("configurable_attribute"),
+						knownvalue.StringExact("two"),
+					),
+				},
+			},
+			// The failure reason reported by the API is shown.
+			{
+				PreConfig: func() {
+					server.SetAsync(&mockapi.Async{Failure: "quota exceeded"})
+				},
+				Config:      testAccExampleResourceConfig(server.URL, "three"),
+				ExpectError: regexp.MustCompile(`failed: quota exceeded`),
+			},
+			{
+				PreConfig: func() {
+					server.SetAsync(&mockapi.Async{})
+				},
+				Config: testAccExampleResourceConfig(server.URL, "three"),
+			},
+		},
+	})
+}
+
+func TestAccExampleResource_ConcurrentChange(t *testing.T) {
+	backend := mockapi.NewBackend()
+
+	var interfere atomic.Bool
+
+	// Stand in for another team changing the example between Terraform
+	// reading it and applying an update.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if interfere.Load() && r.Method == http.MethodPut {
+			changed := "changed elsewhere"
+
+			example, _ := backend.Example("example-1")
+			example.ConfigurableAttribute = &changed
+			backend.PutExample(example)
+		}
+
+		backend.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExampleResourceConfig(server.URL, "one"),
+			},
+			{
+				PreConfig: func() {
+					interfere.Store(true)
+				},
+				Config:      testAccExampleResourceConfig(server.URL, "two"),
+				ExpectError: regexp.MustCompile(`Object Changed Outside of Terraform`),
+			},
+			// The change made elsewhere is not overwritten, and is reverted
+			// once it has been refreshed.
+			{
+				PreConfig: func() {
+					interfere.Store(false)
+
+					if example, _ := backend.Example("example-1"); example.ConfigurableAttribute == nil || *example.ConfigurableAttribute != "changed elsewhere" {
+						t.Errorf("expected change made elsewhere to be kept, got: %v", example.ConfigurableAttribute)
+					}
+				},
+				Config: testAccExampleResourceConfig(server.URL, "two"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("configurable_attribute"),
+						knownvalue.StringExact("two"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccExampleResourceDefaultTagsConfig(endpoint string, owner string) string {
 	return fmt.Sprintf(`
+provider "scaffolding" {
+  endpoint = %[1]q
+
+  default_tags {
+    tags = {
+      owner = %[2]q
+    }
+  }
+
+  ignore_tags {
+    key_prefixes = ["external:"]
+  }
+}
+
+resource "scaffolding_example" "test" {
+  tags = {
+    name = "example"
+  }
+}
+`, endpoint, owner)
+}
+
+func testAccExampleResourceConfig(endpoint string, configurableAttribute string) string {
+	return testAccProviderConfig(endpoint) + fmt.Sprintf(`
 resource "scaffolding_example" "test" {
   configurable_attribute = %[1]q
 }
 `, configurableAttribute)
 }
+
+func testAccExampleResourceProjectConfig(endpoint string, project string) string {
+	return testAccProviderConfig(endpoint) + fmt.Sprintf(`
+resource "scaffolding_example" "test" {
+  project = %[1]q
+}
+`, project)
+}
+
+func testAccExampleResourceRegionConfig(endpoint string, configurableAttribute string, region string) string {
+	return testAccProviderConfig(endpoint) + fmt.Sprintf(`
+resource "scaffolding_example" "test" {
+  configurable_attribute = %[1]q
+  region                 = %[2]q
+}
+`, configurableAttribute, region)
+}
@@ -0,0 +1,97 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ExampleResourceModelV0 describes the resource data model of state version
+// 0, in which the defaulted_attribute attribute was named defaulted. The
+// defaulted attribute remains as a deprecated alias.
+type ExampleResourceModelV0 struct {
+	ConfigurableAttribute types.String   `tfsdk:"configurable_attribute"`
+	Defaulted             types.String   `tfsdk:"defaulted"`
+	Id                    types.String   `tfsdk:"id"`
+	Project               types.String   `tfsdk:"project"`
+	Region                types.String   `tfsdk:"region"`
+	Tags                  types.Map      `tfsdk:"tags"`
+	TagsAll               types.Map      `tfsdk:"tags_all"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+// exampleResourceSchemaV0 returns the schema of state version 0. Only the
+// attribute types are used to read prior state, so descriptions, defaults
+// and plan modifiers are omitted. State written by releases which predate
+// some of these attributes is read with them as null.
+func exampleResourceSchemaV0(ctx context.Context) schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"configurable_attribute": schema.StringAttribute{
+				Optional: true,
+			},
+			"defaulted": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"project": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"region": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"tags": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"tags_all": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// upgradeExampleResourceStateV0 upgrades state version 0 to the current
+// version by copying defaulted to defaulted_attribute.
+func upgradeExampleResourceStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior ExampleResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded := ExampleResourceModel{
+		ConfigurableAttribute: prior.ConfigurableAttribute,
+		Defaulted:             prior.Defaulted,
+		DefaultedAttribute:    prior.Defaulted,
+		Id:                    prior.Id,
+		Project:               prior.Project,
+		Region:                prior.Region,
+		Revision:              types.Int64Null(),
+		Tags:                  prior.Tags,
+		TagsAll:               prior.TagsAll,
+		Timeouts:              prior.Timeouts,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
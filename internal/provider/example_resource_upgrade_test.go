@@ -0,0 +1,178 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestExampleResource_UpgradeStateV0(t *testing.T) {
+	testCases := map[string]struct {
+		rawState string
+		expected ExampleResourceModel
+	}{
+		// State written by the first releases, before most attributes were
+		// added.
+		"initial-release": {
+			rawState: `{"configurable_attribute":"one","defaulted":"example value when not configured","id":"example-id"}`,
+			expected: ExampleResourceModel{
+				ConfigurableAttribute: types.StringValue("one"),
+				DefaultedAttribute:    types.StringValue("example value when not configured"),
+				Id:                    types.StringValue("example-id"),
+				Project:               types.StringNull(),
+				Region:                types.StringNull(),
+				Tags:                  types.MapNull(types.StringType),
+				TagsAll:               types.MapNull(types.StringType),
+			},
+		},
+		"all-attributes": {
+			rawState: `{"configurable_attribute":null,"defaulted":"two","id":"example-1","project":"team","region":"global",` +
+				`"tags":{"name":"example"},"tags_all":{"name":"example"},"timeouts":null}`,
+			expected: ExampleResourceModel{
+				ConfigurableAttribute: types.StringNull(),
+				DefaultedAttribute:    types.StringValue("two"),
+				Id:                    types.StringValue("example-1"),
+				Project:               types.StringValue("team"),
+				Region:                types.StringValue("global"),
+				Tags:                  types.MapValueMust(types.StringType, map[string]attr.Value{"name": types.StringValue("example")}),
+				TagsAll:               types.MapValueMust(types.StringType, map[string]attr.Value{"name": types.StringValue("example")}),
+			},
+		},
+	}
+
+	r := &ExampleResource{}
+
+	var schemaResp resource.SchemaResponse
+
+	r.Schema(t.Context(), resource.SchemaRequest{}, &schemaResp)
+
+	upgrader, ok := r.UpgradeState(t.Context())[0]
+
+	if !ok {
+		t.Fatal("expected a state upgrader from version 0")
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			priorType := upgrader.PriorSchema.Type().TerraformType(t.Context())
+
+			raw, err := (&tfprotov6.RawState{JSON: []byte(testCase.rawState)}).UnmarshalWithOpts(priorType, tfprotov6.UnmarshalOpts{})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			req := resource.UpgradeStateRequest{
+				State: &tfsdk.State{
+					Schema: *upgrader.PriorSchema,
+					Raw:    raw,
+				},
+			}
+
+			resp := resource.UpgradeStateResponse{
+				State: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(t.Context()), nil),
+				},
+			}
+
+			upgrader.StateUpgrader(t.Context(), req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var got ExampleResourceModel
+
+			if diags := resp.State.Get(t.Context(), &got); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if !got.Timeouts.IsNull() {
+				t.Errorf("expected null timeouts, got: %s", got.Timeouts)
+			}
+
+			if !got.ConfigurableAttribute.Equal(testCase.expected.ConfigurableAttribute) ||
+				!got.Defaulted.Equal(testCase.expected.DefaultedAttribute) ||
+				!got.DefaultedAttribute.Equal(testCase.expected.DefaultedAttribute) ||
+				!got.Id.Equal(testCase.expected.Id) ||
+				!got.Project.Equal(testCase.expected.Project) ||
+				!got.Region.Equal(testCase.expected.Region) ||
+				!got.Revision.IsNull() ||
+				!got.Tags.Equal(testCase.expected.Tags) ||
+				!got.TagsAll.Equal(testCase.expected.TagsAll) {
+				t.Errorf("expected upgraded state %+v, got: %+v", testCase.expected, got)
+			}
+		})
+	}
+}
+
+// TestExampleResource_UpgradeResourceStateV0 upgrades raw state version 0
+// through the provider server, as Terraform does when it reads state written
+// before the defaulted attribute was renamed.
+func TestExampleResource_UpgradeResourceStateV0(t *testing.T) {
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	resp, err := server.UpgradeResourceState(t.Context(), &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "scaffolding_example",
+		Version:  0,
+		RawState: &tfprotov6.RawState{
+			JSON: []byte(`{"configurable_attribute":"one","defaulted":"two","id":"example-id","project":"default","region":"global"}`),
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(resp.Diagnostics) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	upgraded, err := resp.UpgradedState.Unmarshal(schemaResp.ResourceSchemas["scaffolding_example"].ValueType())
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var attributes map[string]tftypes.Value
+
+	if err := upgraded.As(&attributes); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]tftypes.Value{
+		"configurable_attribute": tftypes.NewValue(tftypes.String, "one"),
+		"defaulted":              tftypes.NewValue(tftypes.String, "two"),
+		"defaulted_attribute":    tftypes.NewValue(tftypes.String, "two"),
+		"id":                     tftypes.NewValue(tftypes.String, "example-id"),
+		"project":                tftypes.NewValue(tftypes.String, "default"),
+		"region":                 tftypes.NewValue(tftypes.String, "global"),
+		"revision":               tftypes.NewValue(tftypes.Number, nil),
+	}
+
+	for name, value := range expected {
+		if !attributes[name].Equal(value) {
+			t.Errorf("expected %s to be %s, got: %s", name, value, attributes[name])
+		}
+	}
+}
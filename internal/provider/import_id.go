@@ -0,0 +1,100 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// importIDSeparator separates the segments of composite import identifiers.
+const importIDSeparator = "/"
+
+// importIDFormat is a format of composite import identifiers, naming the
+// state attribute each segment is imported into, in order.
+type importIDFormat []string
+
+// String returns the format as shown to practitioners, such as
+// "project/region/id".
+func (f importIDFormat) String() string {
+	return strings.Join(f, importIDSeparator)
+}
+
+// parseImportID splits id into segments and returns them keyed by state
+// attribute name, using the format with the same number of segments. Formats
+// must have distinct numbers of segments, so that short and fully-qualified
+// identifiers can be told apart. Every segment must be non-empty and free of
+// whitespace.
+func parseImportID(id string, formats ...importIDFormat) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	segments := strings.Split(id, importIDSeparator)
+
+	for _, format := range formats {
+		if len(format) != len(segments) {
+			continue
+		}
+
+		result := make(map[string]string, len(format))
+
+		for i, attribute := range format {
+			switch {
+			case segments[i] == "":
+				diags.Append(invalidImportIDDiagnostic(id, fmt.Sprintf("The %s segment is empty.", attribute), formats))
+			case strings.ContainsFunc(segments[i], unicode.IsSpace):
+				diags.Append(invalidImportIDDiagnostic(id, fmt.Sprintf("The %s segment %q contains whitespace.", attribute, segments[i]), formats))
+			}
+
+			result[attribute] = segments[i]
+		}
+
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		return result, diags
+	}
+
+	diags.Append(invalidImportIDDiagnostic(id, fmt.Sprintf("The identifier has %d segments.", len(segments)), formats))
+
+	return nil, diags
+}
+
+// importStateFromID parses id with parseImportID and sets each segment into
+// the state attribute of the same name.
+func importStateFromID(ctx context.Context, id string, state *tfsdk.State, formats ...importIDFormat) diag.Diagnostics {
+	values, diags := parseImportID(id, formats...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	for attribute, value := range values {
+		diags.Append(state.SetAttribute(ctx, path.Root(attribute), value)...)
+	}
+
+	return diags
+}
+
+// invalidImportIDDiagnostic returns an error for an import identifier which
+// cannot be parsed, showing the expected formats.
+func invalidImportIDDiagnostic(id string, reason string, formats []importIDFormat) diag.Diagnostic {
+	expected := make([]string, len(formats))
+
+	for i, format := range formats {
+		expected[i] = "  - " + format.String()
+	}
+
+	return diag.NewErrorDiagnostic(
+		"Invalid Import Identifier",
+		fmt.Sprintf("Unable to parse the import identifier %q. %s\n\n", id, reason)+
+			"Expected an identifier in one of the following formats:\n"+strings.Join(expected, "\n"),
+	)
+}
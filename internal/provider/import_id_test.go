@@ -0,0 +1,81 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseImportID(t *testing.T) {
+	testCases := map[string]struct {
+		id            string
+		expected      map[string]string
+		expectedError string
+	}{
+		"short": {
+			id: "example-1",
+			expected: map[string]string{
+				"id": "example-1",
+			},
+		},
+		"fully-qualified": {
+			id: "team/eu-west/example-1",
+			expected: map[string]string{
+				"project": "team",
+				"region":  "eu-west",
+				"id":      "example-1",
+			},
+		},
+		"empty": {
+			id:            "",
+			expectedError: "The id segment is empty.",
+		},
+		"empty-segment": {
+			id:            "team//example-1",
+			expectedError: "The region segment is empty.",
+		},
+		"whitespace": {
+			id:            "team/eu west/example-1",
+			expectedError: `The region segment "eu west" contains whitespace.`,
+		},
+		"wrong-segment-count": {
+			id:            "team/example-1",
+			expectedError: "The identifier has 2 segments.",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, diags := parseImportID(testCase.id, exampleImportIDFormats...)
+
+			if testCase.expectedError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+
+				if diff := cmp.Diff(testCase.expected, got); diff != "" {
+					t.Errorf("unexpected difference: %s", diff)
+				}
+
+				return
+			}
+
+			if !diags.HasError() {
+				t.Fatalf("expected error, got: %v", got)
+			}
+
+			detail := diags.Errors()[0].Detail()
+
+			// The expected formats are always shown.
+			for _, expected := range []string{testCase.expectedError, "  - id\n", "  - project/region/id"} {
+				if !strings.Contains(detail, expected) {
+					t.Errorf("expected detail to contain %q, got: %s", expected, detail)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// privateStateKeyETag is the private state key holding the ETag of the
+// object as last read or written by the provider.
+const privateStateKeyETag = "etag"
+
+// privateStateKeyImported is the private state key marking an object as
+// imported but not yet read, so that Read can tell imports of objects which
+// do not exist apart from objects managed by Terraform.
+const privateStateKeyImported = "imported"
+
+// privateStateReader reads resource private state, such as the Private field
+// of resource requests.
+type privateStateReader interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateWriter writes resource private state, such as the Private field
+// of resource responses.
+type privateStateWriter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// getETag returns the ETag stored in private state, or an empty string when
+// none is stored, such as for state written by earlier provider versions.
+func getETag(ctx context.Context, private privateStateReader) (string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, privateStateKeyETag)
+
+	if diags.HasError() || len(value) == 0 {
+		return "", diags
+	}
+
+	var etag string
+
+	if err := json.Unmarshal(value, &etag); err != nil {
+		diags.AddError(
+			"Invalid Private State",
+			fmt.Sprintf("Unable to parse the stored ETag, got error: %s. Please report this issue to the provider developers.", err),
+		)
+	}
+
+	return etag, diags
+}
+
+// setETag stores etag in private state. Private state values must be JSON.
+func setETag(ctx context.Context, private privateStateWriter, etag string) diag.Diagnostics {
+	value, err := json.Marshal(etag)
+
+	if err != nil {
+		var diags diag.Diagnostics
+
+		diags.AddError(
+			"Invalid Private State",
+			fmt.Sprintf("Unable to encode the ETag, got error: %s. Please report this issue to the provider developers.", err),
+		)
+
+		return diags
+	}
+
+	return private.SetKey(ctx, privateStateKeyETag, value)
+}
+
+// getImported reports whether the object was imported and not yet read.
+func getImported(ctx context.Context, private privateStateReader) (bool, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, privateStateKeyImported)
+
+	return len(value) > 0, diags
+}
+
+// setImported marks the object as imported, or removes the mark once the
+// imported object has been read.
+func setImported(ctx context.Context, private privateStateWriter, imported bool) diag.Diagnostics {
+	if !imported {
+		// Keys are removed by setting them to an empty value.
+		return private.SetKey(ctx, privateStateKeyImported, nil)
+	}
+
+	return private.SetKey(ctx, privateStateKeyImported, []byte("true"))
+}
@@ -0,0 +1,76 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// testPrivateState is an in-memory resource private state.
+type testPrivateState map[string][]byte
+
+func (s testPrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return s[key], nil
+}
+
+func (s testPrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	s[key] = value
+
+	return nil
+}
+
+func TestETagPrivateState(t *testing.T) {
+	private := testPrivateState{}
+
+	etag, diags := getETag(t.Context(), private)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if etag != "" {
+		t.Errorf("expected no ETag before one is stored, got %q", etag)
+	}
+
+	if diags := setETag(t.Context(), private, `"abc123"`); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if stored := string(private[privateStateKeyETag]); stored != `"\"abc123\""` {
+		t.Errorf("expected ETag to be stored as JSON, got %s", stored)
+	}
+
+	etag, diags = getETag(t.Context(), private)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if etag != `"abc123"` {
+		t.Errorf("expected stored ETag, got %q", etag)
+	}
+}
+
+func TestImportedPrivateState(t *testing.T) {
+	private := testPrivateState{}
+
+	for _, expected := range []bool{true, false} {
+		if diags := setImported(t.Context(), private, expected); diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		imported, diags := getImported(t.Context(), private)
+
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		if imported != expected {
+			t.Errorf("expected imported %t, got %t", expected, imported)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// DefaultProfile is the name of the profile used when none is configured.
+const DefaultProfile = "default"
+
+// profileKeys are the settings a credentials file profile may contain. Keys
+// of nested provider attributes join the block and attribute names with an
+// underscore, such as oauth2_client_id.
+var profileKeys = []string{
+	"endpoint",
+	"oauth2_client_id",
+	"oauth2_client_secret",
+	"oauth2_scopes",
+	"oauth2_token_url",
+	"password",
+	"token",
+	"username",
+}
+
+// profileParseError describes a syntax error in a credentials file.
+type profileParseError struct {
+	Line int
+	Err  error
+}
+
+func (e *profileParseError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e *profileParseError) Unwrap() error {
+	return e.Err
+}
+
+// defaultConfigFile returns the path of the credentials file used when none
+// is configured, ~/.config/scaffolding/credentials.
+func defaultConfigFile() (string, error) {
+	home, err := os.UserHomeDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".config", "scaffolding", "credentials"), nil
+}
+
+// readProfiles reads the named profiles from a credentials file. An error
+// wrapping fs.ErrNotExist is returned when the file does not exist, and an
+// error is returned when the file is readable by users other than its owner.
+func readProfiles(name string) (map[string]map[string]string, error) {
+	f, err := os.Open(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Windows does not report Unix permission bits.
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("permissions %04o allow access by other users, restrict them with: chmod 600 %s", info.Mode().Perm(), name)
+	}
+
+	return parseProfiles(f)
+}
+
+// parseProfiles parses credentials file content in INI format, such as:
+//
+//	[default]
+//	endpoint = https://api.example.com
+//	token    = example-token
+//
+// Blank lines and lines starting with # or ; are ignored. Settings must
+// belong to a profile, and unknown settings are rejected so that typos are
+// reported rather than silently ignored.
+func parseProfiles(r io.Reader) (map[string]map[string]string, error) {
+	profiles := make(map[string]map[string]string)
+
+	var current map[string]string
+
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case text == "", strings.HasPrefix(text, "#"), strings.HasPrefix(text, ";"):
+			continue
+		case strings.HasPrefix(text, "["):
+			name, ok := strings.CutSuffix(text[1:], "]")
+			name = strings.TrimSpace(name)
+
+			if !ok || name == "" {
+				return nil, &profileParseError{Line: line, Err: fmt.Errorf("invalid profile header %q", text)}
+			}
+
+			if _, ok := profiles[name]; ok {
+				return nil, &profileParseError{Line: line, Err: fmt.Errorf("duplicate profile %q", name)}
+			}
+
+			current = make(map[string]string)
+			profiles[name] = current
+		default:
+			key, value, ok := strings.Cut(text, "=")
+			key = strings.TrimSpace(key)
+
+			if !ok || key == "" {
+				return nil, &profileParseError{Line: line, Err: fmt.Errorf("expected key = value, got %q", text)}
+			}
+
+			if current == nil {
+				return nil, &profileParseError{Line: line, Err: fmt.Errorf("setting %q must follow a [profile] header", key)}
+			}
+
+			if !slices.Contains(profileKeys, key) {
+				return nil, &profileParseError{Line: line, Err: fmt.Errorf("unknown setting %q, expected one of: %s", key, strings.Join(profileKeys, ", "))}
+			}
+
+			current[key] = strings.TrimSpace(value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
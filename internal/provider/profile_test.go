@@ -0,0 +1,125 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseProfiles(t *testing.T) {
+	testCases := map[string]struct {
+		content     string
+		expected    map[string]map[string]string
+		expectLine  int
+		expectError string
+	}{
+		"profiles": {
+			content: `
+# Shared credentials
+[default]
+endpoint = https://api.example.com
+token=example-token
+
+; Staging environment
+[ staging ]
+oauth2_client_id     = client
+oauth2_client_secret = secret=with=equals
+`,
+			expected: map[string]map[string]string{
+				"default": {
+					"endpoint": "https://api.example.com",
+					"token":    "example-token",
+				},
+				"staging": {
+					"oauth2_client_id":     "client",
+					"oauth2_client_secret": "secret=with=equals",
+				},
+			},
+		},
+		"unknown-setting": {
+			content:     "[default]\nendpoint = https://api.example.com\ntokne = example-token\n",
+			expectLine:  3,
+			expectError: `unknown setting "tokne"`,
+		},
+		"setting-outside-profile": {
+			content:     "token = example-token\n",
+			expectLine:  1,
+			expectError: "must follow a [profile] header",
+		},
+		"invalid-header": {
+			content:     "[default\n",
+			expectLine:  1,
+			expectError: "invalid profile header",
+		},
+		"duplicate-profile": {
+			content:     "[default]\n[default]\n",
+			expectLine:  2,
+			expectError: `duplicate profile "default"`,
+		},
+		"missing-value": {
+			content:     "[default]\n\ntoken\n",
+			expectLine:  3,
+			expectError: "expected key = value",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseProfiles(strings.NewReader(testCase.content))
+
+			if testCase.expectError != "" {
+				var parseErr *profileParseError
+
+				if !errors.As(err, &parseErr) {
+					t.Fatalf("expected parse error, got: %v", err)
+				}
+
+				if parseErr.Line != testCase.expectLine || !strings.Contains(err.Error(), testCase.expectError) {
+					t.Errorf("expected error containing %q at line %d, got: %s", testCase.expectError, testCase.expectLine, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestReadProfiles_Permissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not checked on Windows")
+	}
+
+	configFile := filepath.Join(t.TempDir(), "credentials")
+
+	if err := os.WriteFile(configFile, []byte("[default]\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := readProfiles(configFile); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := os.Chmod(configFile, 0o644); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := readProfiles(configFile); err == nil || !strings.Contains(err.Error(), "chmod 600") {
+		t.Errorf("expected permissions error, got: %v", err)
+	}
+}
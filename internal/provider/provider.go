@@ -5,16 +5,25 @@ package provider
 
 import (
 	"context"
-	"net/http"
+	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
 )
 
 // Ensure ScaffoldingProvider satisfies various provider interfaces.
@@ -22,6 +31,9 @@ var _ provider.Provider = &ScaffoldingProvider{}
 var _ provider.ProviderWithFunctions = &ScaffoldingProvider{}
 var _ provider.ProviderWithEphemeralResources = &ScaffoldingProvider{}
 var _ provider.ProviderWithActions = &ScaffoldingProvider{}
+var _ provider.ProviderWithConfigValidators = &ScaffoldingProvider{}
+var _ provider.ProviderWithValidateConfig = &ScaffoldingProvider{}
+var _ provider.ProviderWithMetaSchema = &ScaffoldingProvider{}
 
 // ScaffoldingProvider defines the provider implementation.
 type ScaffoldingProvider struct {
@@ -33,7 +45,58 @@ type ScaffoldingProvider struct {
 
 // ScaffoldingProviderModel describes the provider data model.
 type ScaffoldingProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
+	Profile    types.String `tfsdk:"profile"`
+	ConfigFile types.String `tfsdk:"config_file"`
+
+	Endpoint types.String                    `tfsdk:"endpoint"`
+	Token    types.String                    `tfsdk:"token"`
+	Username types.String                    `tfsdk:"username"`
+	Password types.String                    `tfsdk:"password"`
+	OAuth2   *ScaffoldingProviderOAuth2Model `tfsdk:"oauth2"`
+
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait types.String `tfsdk:"retry_max_wait"`
+
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	CacheReads            types.Bool    `tfsdk:"cache_reads"`
+
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	UserAgentSuffix types.String `tfsdk:"user_agent_suffix"`
+
+	Mock          types.Bool   `tfsdk:"mock"`
+	MockStateFile types.String `tfsdk:"mock_state_file"`
+
+	DefaultTags *ScaffoldingProviderDefaultTagsModel `tfsdk:"default_tags"`
+	IgnoreTags  *ScaffoldingProviderIgnoreTagsModel  `tfsdk:"ignore_tags"`
+
+	DefaultTimeouts *ScaffoldingProviderDefaultTimeoutsModel `tfsdk:"default_timeouts"`
+}
+
+// resourceProviderData is the provider data shared with resources.
+type resourceProviderData struct {
+	client   *client.Client
+	tags     tagPolicy
+	timeouts operationTimeouts
+}
+
+// dataSourceProviderData is the provider data shared with data sources.
+type dataSourceProviderData struct {
+	client      *client.Client
+	readTimeout time.Duration
+}
+
+// ScaffoldingProviderOAuth2Model describes the oauth2 block data model.
+type ScaffoldingProviderOAuth2Model struct {
+	TokenURL     types.String `tfsdk:"token_url"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Scopes       types.List   `tfsdk:"scopes"`
 }
 
 func (p *ScaffoldingProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -43,15 +106,259 @@ func (p *ScaffoldingProvider) Metadata(ctx context.Context, req provider.Metadat
 
 func (p *ScaffoldingProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "Interact with the scaffolding API.\n\n" +
+			"Each setting is taken from the first of the following sources which sets it: " +
+			"the provider configuration, then `SCAFFOLDING_*` environment variables, then the credentials file profile, then defaults. " +
+			"Authentication settings are resolved together, so authentication settings from later sources " +
+			"are ignored when an earlier source sets any authentication setting.\n\n" +
+			"The credentials file holds named profiles in INI format, each of which may set `endpoint`, `token`, `username`, `password`, " +
+			"`oauth2_token_url`, `oauth2_client_id`, `oauth2_client_secret` and comma-separated `oauth2_scopes`. " +
+			"The file must not be accessible by users other than its owner.\n\n" +
+			"```ini\n[default]\nendpoint = https://api.example.com\ntoken    = example-token\n\n" +
+			"[staging]\nendpoint = https://staging.example.com\nusername = example-user\npassword = example-password\n```",
 		Attributes: map[string]schema.Attribute{
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Name of the credentials file profile to read settings from. May also be set with the `" + EnvProfile + "` environment variable. " +
+					"Defaults to `" + DefaultProfile + "`, which is only read when present.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"config_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the credentials file. May also be set with the `" + EnvConfigFile + "` environment variable. " +
+					"Defaults to `~/.config/scaffolding/credentials`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "Example provider attribute",
+				MarkdownDescription: "Base URL of the API, such as `https://api.example.com/v1`. Must use the `http` or `https` scheme. May also be set with the `" + EnvEndpoint + "` environment variable. Defaults to `" + client.DefaultEndpoint + "`.",
+				Optional:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Static bearer token used to authenticate with the API. May also be set with the `" + EnvToken + "` environment variable. Conflicts with `username`, `password` and `oauth2`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username used for HTTP basic authentication. May also be set with the `" + EnvUsername + "` environment variable. Must be set together with `password`.",
+				Optional:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password used for HTTP basic authentication. May also be set with the `" + EnvPassword + "` environment variable. Must be set together with `username`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of times a request failing with a transient error, such as `429 Too Many Requests` "+
+					"or `503 Service Unavailable`, is retried. Only idempotent requests are retried. Set to `0` to disable retries. Defaults to `%d`.", client.DefaultMaxRetries),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Longest time to wait between retries, such as `30s`. Waits between retries grow exponentially up to this value. "+
+					"Responses whose `Retry-After` header asks for a longer wait are not retried. Defaults to `%s`.", client.DefaultRetryMaxWait),
+				Optional: true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum sustained rate of API requests, shared by all resources and data sources. " +
+					"Requests beyond the rate wait rather than fail. Unlimited when not set.",
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.01),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests in flight at once, shared by all resources and data sources. " +
+					"Further requests wait for an earlier request to complete. Unlimited when not set.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"cache_reads": schema.BoolAttribute{
+				MarkdownDescription: "Cache API reads for the duration of each Terraform command, so that objects read by several resources and data sources are fetched once. " +
+					"Identical reads in flight at the same time share a single request, and cached objects are read again after the provider changes them. " +
+					"Changes made outside of Terraform during a command may not be seen. Defaults to `false`.",
+				Optional: true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA certificates to trust in addition to the system certificate pool, " +
+					"such as a private CA which issued the API server certificate. Conflicts with `ca_cert_file`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file of PEM-encoded CA certificates to trust in addition to the system certificate pool. " +
+					"Conflicts with `ca_cert_pem`.",
+				Optional: true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded client certificate presented for mutual TLS authentication. Must be set together with `client_key_pem`.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_pem")),
+				},
+			},
+			"client_key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded private key of `client_cert_pem`. Must be set together with `client_cert_pem`.",
 				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_pem")),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Disable verification of the API server certificate chain and host name. " +
+					"This exposes API traffic, including credentials, to interception and should only be used for testing. Defaults to `false`.",
+				Optional: true,
+			},
+			"mock": schema.BoolAttribute{
+				MarkdownDescription: "Serve API requests from an in-process mock API instead of `endpoint`, for offline demonstrations and testing. " +
+					"May also be set with the `" + EnvMock + "` environment variable. Defaults to `false`.",
+				Optional: true,
+			},
+			"mock_state_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the JSON file in which the mock API persists objects between Terraform runs. " +
+					"Concurrent Terraform runs sharing the file take turns, holding a lock on the path with a `.lock` suffix. " +
+					"May also be set with the `" + EnvMockStateFile + "` environment variable. Defaults to `" + DefaultMockStateFile + "` in the working directory.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Text appended to the `User-Agent` header of API requests, such as the name of the pipeline running Terraform. " +
+					"The header always identifies the provider and Terraform versions.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"default_tags": schema.SingleNestedBlock{
+				MarkdownDescription: "Tags applied to every resource which supports tags. " +
+					"Tags set on a resource override default tags with the same key.",
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.MapAttribute{
+						MarkdownDescription: "Map of tags to apply to every resource.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
+			"default_timeouts": schema.SingleNestedBlock{
+				MarkdownDescription: "Longest time each operation on resources and data sources may take before it is abandoned, such as `30m`. " +
+					"An operation includes waiting for requests to be retried or rate limited. Resources may override these with a `timeouts` attribute.",
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("Timeout for creating resources. Defaults to `%s`.", DefaultCreateTimeout),
+						Optional:            true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+					},
+					"read": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("Timeout for reading resources and data sources. Defaults to `%s`.", DefaultReadTimeout),
+						Optional:            true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+					},
+					"update": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("Timeout for updating resources. Defaults to `%s`.", DefaultUpdateTimeout),
+						Optional:            true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+					},
+					"delete": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("Timeout for deleting resources. Defaults to `%s`.", DefaultDeleteTimeout),
+						Optional:            true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+					},
+				},
+			},
+			"ignore_tags": schema.SingleNestedBlock{
+				MarkdownDescription: "Tags managed outside of Terraform, which are neither applied nor reported as changes.",
+				Attributes: map[string]schema.Attribute{
+					"keys": schema.SetAttribute{
+						MarkdownDescription: "Tag keys to ignore.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+					"key_prefixes": schema.SetAttribute{
+						MarkdownDescription: "Tag key prefixes to ignore.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
+			"oauth2": schema.SingleNestedBlock{
+				MarkdownDescription: "Authenticate using the OAuth 2.0 client credentials grant. " +
+					"Access tokens are requested on first use and refreshed automatically before they expire. " +
+					"When the block is absent, the `" + EnvOAuth2TokenURL + "`, `" + EnvOAuth2ClientID + "`, `" + EnvOAuth2ClientSecret + "` " +
+					"and comma-separated `" + EnvOAuth2Scopes + "` environment variables may be used instead.",
+				Attributes: map[string]schema.Attribute{
+					"token_url": schema.StringAttribute{
+						MarkdownDescription: "URL of the authorization server token endpoint.",
+						Required:            true,
+					},
+					"client_id": schema.StringAttribute{
+						MarkdownDescription: "OAuth 2.0 client identifier.",
+						Required:            true,
+					},
+					"client_secret": schema.StringAttribute{
+						MarkdownDescription: "OAuth 2.0 client secret.",
+						Required:            true,
+						Sensitive:           true,
+					},
+					"scopes": schema.ListAttribute{
+						MarkdownDescription: "Scopes to request with each access token.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
 			},
 		},
 	}
 }
 
+func (p *ScaffoldingProvider) ConfigValidators(ctx context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		providervalidator.RequiredTogether(
+			path.MatchRoot("username"),
+			path.MatchRoot("password"),
+		),
+	}
+}
+
+func (p *ScaffoldingProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var data ScaffoldingProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateEndpoint(data.Endpoint)...)
+	resp.Diagnostics.Append(validateAuthModes(data)...)
+}
+
 func (p *ScaffoldingProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var data ScaffoldingProviderModel
 
@@ -61,13 +368,106 @@ func (p *ScaffoldingProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
-	// Configuration values are now available.
-	// if data.Endpoint.IsNull() { /* ... */ }
+	// Configuration derived from resources which are not yet created, such as
+	// an endpoint output by another resource, is unknown until apply.
+	if unknown := unknownAttributes(req.Config); len(unknown) > 0 {
+		if req.ClientCapabilities.DeferralAllowed {
+			tflog.Debug(ctx, "Deferring provider configuration with unknown values", map[string]any{
+				"attributes": unknown,
+			})
+
+			resp.Deferred = &provider.Deferred{
+				Reason: provider.DeferredReasonProviderConfigUnknown,
+			}
+
+			return
+		}
+
+		for _, attribute := range unknown {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Unknown Provider Configuration",
+				fmt.Sprintf("The provider cannot create the API client as the %s value is unknown until apply, ", attribute)+
+					"and this version of Terraform does not support deferring changes until it is known. "+
+					"Either set the value statically, apply the resources it depends on first using the -target option, "+
+					"or use a version of Terraform with deferred actions enabled.",
+			)
+		}
+
+		return
+	}
+
+	clientConfig, diags := newClientConfig(ctx, data)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tags, diags := newTagPolicy(ctx, data)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeouts, diags := newOperationTimeouts(data)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clientConfig.SensitiveFields = p.sensitiveAttributeNames(ctx)
+	clientConfig.UserAgent = userAgent(p.version, req.TerraformVersion, data.UserAgentSuffix.ValueString())
+
+	apiClient, err := client.New(clientConfig)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create API Client",
+			"An unexpected error occurred when creating the API client. "+
+				"Verify the endpoint is a valid URL and the authentication settings are complete.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	// Discover the features offered by the server once, so that resources
+	// can check for them while planning.
+	discoverCtx, cancel := withOperationTimeout(ctx, "read", timeouts.read)
+	defer cancel()
+
+	capabilities, err := apiClient.DiscoverCapabilities(discoverCtx)
+
+	if err != nil {
+		addClientError(discoverCtx, &resp.Diagnostics, "discover API capabilities", err)
+
+		return
+	}
+
+	tflog.Debug(ctx, "Discovered API capabilities", map[string]any{
+		"api_version": capabilities.APIVersion,
+		"features":    capabilities.Features,
+	})
 
-	// Example client configuration for data sources and resources
-	client := http.DefaultClient
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	// Share the client with data sources, resources, ephemeral resources and
+	// actions
+	resp.DataSourceData = &dataSourceProviderData{
+		client:      apiClient,
+		readTimeout: timeouts.read,
+	}
+	resp.ResourceData = &resourceProviderData{
+		client:   apiClient,
+		tags:     tags,
+		timeouts: timeouts,
+	}
+	resp.EphemeralResourceData = apiClient
+	resp.ActionData = apiClient
 }
 
 func (p *ScaffoldingProvider) Resources(ctx context.Context) []func() resource.Resource {
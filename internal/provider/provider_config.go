@@ -0,0 +1,635 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client/mockapi"
+)
+
+// Environment variables used when the corresponding provider attributes are
+// not configured.
+const (
+	EnvEndpoint           = "SCAFFOLDING_ENDPOINT"
+	EnvToken              = "SCAFFOLDING_TOKEN"
+	EnvUsername           = "SCAFFOLDING_USERNAME"
+	EnvPassword           = "SCAFFOLDING_PASSWORD"
+	EnvOAuth2TokenURL     = "SCAFFOLDING_OAUTH2_TOKEN_URL"
+	EnvOAuth2ClientID     = "SCAFFOLDING_OAUTH2_CLIENT_ID"
+	EnvOAuth2ClientSecret = "SCAFFOLDING_OAUTH2_CLIENT_SECRET"
+	EnvOAuth2Scopes       = "SCAFFOLDING_OAUTH2_SCOPES"
+	EnvProfile            = "SCAFFOLDING_PROFILE"
+	EnvConfigFile         = "SCAFFOLDING_CONFIG_FILE"
+	EnvMock               = "SCAFFOLDING_MOCK"
+	EnvMockStateFile      = "SCAFFOLDING_MOCK_STATE_FILE"
+)
+
+// DefaultMockStateFile is the path of the mock API state file used when none
+// is configured, relative to the Terraform working directory.
+const DefaultMockStateFile = ".scaffolding-mock.json"
+
+// Sources of effective provider configuration values, in order of
+// precedence.
+const (
+	sourceConfig  = "provider configuration"
+	sourceEnv     = "environment"
+	sourceProfile = "profile"
+	sourceDefault = "default"
+)
+
+// configResolver resolves effective provider configuration values and logs
+// which source each value came from.
+type configResolver struct {
+	ctx context.Context
+
+	// profile holds the settings of the selected credentials file profile,
+	// if any, and profileSource describes where it was read from.
+	profile       map[string]string
+	profileSource string
+}
+
+// string returns the configured value when not null, otherwise the value of
+// the environment variable when set, otherwise the value in the credentials
+// file profile when set, otherwise defaultValue.
+func (r configResolver) string(attribute string, value types.String, envVar string, defaultValue string) string {
+	if !value.IsNull() {
+		return r.config(attribute, value)
+	}
+
+	if v := r.env(attribute, envVar); v != "" {
+		return v
+	}
+
+	if v := r.fromProfile(attribute); v != "" {
+		return v
+	}
+
+	if defaultValue != "" {
+		r.log(attribute, sourceDefault)
+	}
+
+	return defaultValue
+}
+
+// config returns the configured value, logging the provider configuration
+// as the source of attribute when not null.
+func (r configResolver) config(attribute string, value types.String) string {
+	if !value.IsNull() {
+		r.log(attribute, sourceConfig)
+	}
+
+	return value.ValueString()
+}
+
+// env returns the value of the environment variable, logging it as the source
+// of attribute when set.
+func (r configResolver) env(attribute string, envVar string) string {
+	v := os.Getenv(envVar)
+
+	if v != "" {
+		r.log(attribute, sourceEnv+" variable "+envVar)
+	}
+
+	return v
+}
+
+// fromProfile returns the value of attribute in the credentials file profile,
+// logging the profile as the source of attribute when set. Nested attributes
+// such as oauth2.client_id are stored as oauth2_client_id.
+func (r configResolver) fromProfile(attribute string) string {
+	v := r.profile[strings.ReplaceAll(attribute, ".", "_")]
+
+	if v != "" {
+		r.log(attribute, r.profileSource)
+	}
+
+	return v
+}
+
+func (r configResolver) log(attribute string, source string) {
+	// Values are deliberately omitted as several attributes are sensitive.
+	tflog.Debug(r.ctx, "Resolved provider configuration value", map[string]any{
+		"attribute": attribute,
+		"source":    source,
+	})
+}
+
+// newClientConfig resolves the effective API client configuration from the
+// provider data model and environment variables. Each value is taken from
+// the first of the following sources which sets it:
+//
+//  1. The provider configuration.
+//  2. Environment variables, for endpoint and authentication settings.
+//  3. The credentials file profile, for endpoint and authentication settings.
+//  4. Defaults.
+//
+// Authentication settings are resolved as a unit, so later sources are not
+// consulted for authentication when an earlier source sets any
+// authentication setting. An error diagnostic is returned when more than one
+// authentication mode is set.
+func newClientConfig(ctx context.Context, data ScaffoldingProviderModel) (client.Config, diag.Diagnostics) {
+	r := configResolver{ctx: ctx}
+
+	diags := r.loadProfile(data)
+
+	cfg := client.Config{
+		Endpoint:     r.string("endpoint", data.Endpoint, EnvEndpoint, client.DefaultEndpoint),
+		MaxRetries:   client.DefaultMaxRetries,
+		RetryMaxWait: client.DefaultRetryMaxWait,
+	}
+
+	if !data.MaxRetries.IsNull() {
+		r.log("max_retries", sourceConfig)
+
+		cfg.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+
+	if !data.RetryMaxWait.IsNull() {
+		r.log("retry_max_wait", sourceConfig)
+
+		wait, err := time.ParseDuration(data.RetryMaxWait.ValueString())
+
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("retry_max_wait"),
+				"Invalid Retry Maximum Wait",
+				fmt.Sprintf("Unable to parse retry_max_wait as a duration: %s", err),
+			)
+		}
+
+		cfg.RetryMaxWait = wait
+	}
+
+	if !data.RequestsPerSecond.IsNull() {
+		r.log("requests_per_second", sourceConfig)
+
+		cfg.RequestsPerSecond = data.RequestsPerSecond.ValueFloat64()
+	}
+
+	if !data.MaxConcurrentRequests.IsNull() {
+		r.log("max_concurrent_requests", sourceConfig)
+
+		cfg.MaxConcurrentRequests = int(data.MaxConcurrentRequests.ValueInt64())
+	}
+
+	if !data.CacheReads.IsNull() {
+		r.log("cache_reads", sourceConfig)
+
+		cfg.CacheReads = data.CacheReads.ValueBool()
+	}
+
+	tlsConfig, tlsDiags := newTLSConfig(r, data)
+
+	diags.Append(tlsDiags...)
+
+	cfg.TLS = tlsConfig
+
+	mockHTTPClient, mockDiags := newMockHTTPClient(r, data)
+
+	diags.Append(mockDiags...)
+
+	// The mock API is served in-process, so TLS settings do not apply.
+	if mockHTTPClient != nil {
+		cfg.HTTPClient = mockHTTPClient
+		cfg.TLS = nil
+	}
+
+	authSource := sourceConfig
+
+	if data.Token.IsNull() && data.Username.IsNull() && data.Password.IsNull() && data.OAuth2 == nil {
+		authSource = sourceEnv
+
+		if !envAuthSet() {
+			authSource = sourceProfile
+		}
+	}
+
+	if authSource == sourceConfig {
+		cfg.Token = r.config("token", data.Token)
+		cfg.Username = r.config("username", data.Username)
+		cfg.Password = r.config("password", data.Password)
+
+		if data.OAuth2 != nil {
+			r.log("oauth2", sourceConfig)
+
+			cfg.OAuth2 = &client.OAuth2Config{
+				TokenURL:     data.OAuth2.TokenURL.ValueString(),
+				ClientID:     data.OAuth2.ClientID.ValueString(),
+				ClientSecret: data.OAuth2.ClientSecret.ValueString(),
+			}
+
+			diags.Append(data.OAuth2.Scopes.ElementsAs(ctx, &cfg.OAuth2.Scopes, false)...)
+		}
+	} else {
+		lookup := r.env
+
+		if authSource == sourceProfile {
+			lookup = func(attribute string, _ string) string {
+				return r.fromProfile(attribute)
+			}
+		}
+
+		cfg.Token = lookup("token", EnvToken)
+		cfg.Username = lookup("username", EnvUsername)
+		cfg.Password = lookup("password", EnvPassword)
+
+		oauth2 := client.OAuth2Config{
+			TokenURL:     lookup("oauth2.token_url", EnvOAuth2TokenURL),
+			ClientID:     lookup("oauth2.client_id", EnvOAuth2ClientID),
+			ClientSecret: lookup("oauth2.client_secret", EnvOAuth2ClientSecret),
+		}
+
+		if scopes := lookup("oauth2.scopes", EnvOAuth2Scopes); scopes != "" {
+			oauth2.Scopes = strings.Split(scopes, ",")
+		}
+
+		if oauth2.TokenURL != "" || oauth2.ClientID != "" || oauth2.ClientSecret != "" || oauth2.Scopes != nil {
+			cfg.OAuth2 = &oauth2
+		}
+	}
+
+	if modes := cfg.AuthModes(); len(modes) > 1 {
+		diags.AddError(
+			"Conflicting Authentication Configuration",
+			fmt.Sprintf("The %s settings configure more than one authentication mode: %s. ", authSource, strings.Join(modes, ", "))+
+				"Configure only one of token, username and password, or oauth2.",
+		)
+	}
+
+	if (cfg.Username == "") != (cfg.Password == "") {
+		diags.AddError(
+			"Incomplete Basic Authentication Configuration",
+			fmt.Sprintf("The %s settings must configure username and password together.", authSource),
+		)
+	}
+
+	if cfg.OAuth2 != nil && (cfg.OAuth2.TokenURL == "" || cfg.OAuth2.ClientID == "" || cfg.OAuth2.ClientSecret == "") {
+		diags.AddError(
+			"Incomplete OAuth 2.0 Configuration",
+			fmt.Sprintf("The %s settings must configure oauth2 token_url, client_id and client_secret together.", authSource),
+		)
+	}
+
+	return cfg, diags
+}
+
+// envAuthSet returns whether any authentication environment variable is set,
+// in which case authentication settings are taken from the environment alone.
+func envAuthSet() bool {
+	for _, envVar := range []string{EnvToken, EnvUsername, EnvPassword, EnvOAuth2TokenURL, EnvOAuth2ClientID, EnvOAuth2ClientSecret, EnvOAuth2Scopes} {
+		if os.Getenv(envVar) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// loadProfile reads the credentials file profile selected by the profile and
+// config_file settings into r. The default profile of the default credentials
+// file is used when present, but it is an error for an explicitly selected
+// file or profile to be missing.
+func (r *configResolver) loadProfile(data ScaffoldingProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	profileName := r.string("profile", data.Profile, EnvProfile, "")
+	configFile := r.string("config_file", data.ConfigFile, EnvConfigFile, "")
+	explicit := profileName != "" || configFile != ""
+
+	if profileName == "" {
+		profileName = DefaultProfile
+	}
+
+	if configFile == "" {
+		var err error
+
+		configFile, err = defaultConfigFile()
+
+		if err != nil {
+			if explicit {
+				diags.AddAttributeError(
+					path.Root("config_file"),
+					"Unable to Locate Credentials File",
+					fmt.Sprintf("Unable to determine the default credentials file location, configure config_file instead: %s", err),
+				)
+			}
+
+			return diags
+		}
+	}
+
+	profiles, err := readProfiles(configFile)
+
+	var parseErr *profileParseError
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist) && !explicit:
+		return diags
+	case errors.As(err, &parseErr):
+		diags.AddAttributeError(
+			path.Root("config_file"),
+			"Invalid Credentials File",
+			fmt.Sprintf("Unable to parse credentials file %s at line %d: %s", configFile, parseErr.Line, parseErr.Err),
+		)
+
+		return diags
+	case err != nil:
+		diags.AddAttributeError(
+			path.Root("config_file"),
+			"Unable to Read Credentials File",
+			fmt.Sprintf("Unable to read credentials file %s: %s", configFile, err),
+		)
+
+		return diags
+	}
+
+	profile, ok := profiles[profileName]
+
+	if !ok {
+		if explicit {
+			diags.AddAttributeError(
+				path.Root("profile"),
+				"Profile Not Found",
+				fmt.Sprintf("Profile %q was not found in credentials file %s.", profileName, configFile),
+			)
+		}
+
+		return diags
+	}
+
+	r.profile = profile
+	r.profileSource = fmt.Sprintf("%s %q in %s", sourceProfile, profileName, configFile)
+
+	return diags
+}
+
+// newTLSConfig returns the TLS configuration described by the provider data
+// model, or nil when no TLS settings are configured. A warning diagnostic is
+// returned when server certificate verification is disabled.
+func newTLSConfig(r configResolver, data ScaffoldingProviderModel) (*client.TLSConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if data.CACertPEM.IsNull() && data.CACertFile.IsNull() && data.ClientCertPEM.IsNull() && data.ClientKeyPEM.IsNull() && !data.InsecureSkipVerify.ValueBool() {
+		return nil, diags
+	}
+
+	cfg := &client.TLSConfig{
+		CACertPEM:          r.config("ca_cert_pem", data.CACertPEM),
+		ClientCertPEM:      r.config("client_cert_pem", data.ClientCertPEM),
+		ClientKeyPEM:       r.config("client_key_pem", data.ClientKeyPEM),
+		InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+	}
+
+	if caCertFile := r.config("ca_cert_file", data.CACertFile); caCertFile != "" {
+		caCertPEM, err := os.ReadFile(caCertFile)
+
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Unable to Read CA Certificate File",
+				fmt.Sprintf("Unable to read CA certificate file %q: %s", caCertFile, err),
+			)
+		}
+
+		cfg.CACertPEM = string(caCertPEM)
+	}
+
+	if cfg.InsecureSkipVerify {
+		r.log("insecure_skip_verify", sourceConfig)
+
+		diags.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"Insecure TLS Verification Enabled",
+			"The provider will not verify the API server certificate chain or host name. "+
+				"API traffic, including credentials, may be intercepted or modified by a third party. "+
+				"Only use insecure_skip_verify for testing, and configure ca_cert_pem or ca_cert_file to trust a private CA instead.",
+		)
+	}
+
+	return cfg, diags
+}
+
+// unknownAttributes returns the sorted names of the provider attributes and
+// blocks whose values are not fully known.
+func unknownAttributes(config tfsdk.Config) []string {
+	var values map[string]tftypes.Value
+
+	if err := config.Raw.As(&values); err != nil {
+		return nil
+	}
+
+	var unknown []string
+
+	for name, value := range values {
+		if !value.IsFullyKnown() {
+			unknown = append(unknown, name)
+		}
+	}
+
+	slices.Sort(unknown)
+
+	return unknown
+}
+
+// newMockHTTPClient returns an HTTP client which serves API requests from an
+// in-process mock API when mock mode is enabled, otherwise nil. The mock API
+// state is persisted to a JSON file so that it survives between Terraform
+// runs.
+func newMockHTTPClient(r configResolver, data ScaffoldingProviderModel) (*http.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	enabled := data.Mock.ValueBool()
+
+	if data.Mock.IsNull() {
+		if v := r.env("mock", EnvMock); v != "" {
+			var err error
+
+			enabled, err = strconv.ParseBool(v)
+
+			if err != nil {
+				diags.AddError(
+					"Invalid Mock Mode Environment Variable",
+					fmt.Sprintf("Unable to parse %s as a boolean, got: %q.", EnvMock, v),
+				)
+
+				return nil, diags
+			}
+		}
+	} else {
+		r.log("mock", sourceConfig)
+	}
+
+	if !enabled {
+		return nil, diags
+	}
+
+	stateFile := r.string("mock_state_file", data.MockStateFile, EnvMockStateFile, DefaultMockStateFile)
+
+	backend, err := mockapi.NewFileBackend(stateFile)
+
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("mock_state_file"),
+			"Unable to Load Mock API State",
+			fmt.Sprintf("Unable to load mock API state file %q: %s", stateFile, err),
+		)
+
+		return nil, diags
+	}
+
+	tflog.Info(r.ctx, "Using in-process mock API", map[string]any{
+		"state_file": stateFile,
+	})
+
+	return &http.Client{Transport: backend}, diags
+}
+
+// validateEndpoint returns an error diagnostic when the configured endpoint
+// is not a valid API URL. Unknown values are validated when the provider is
+// configured.
+func validateEndpoint(endpoint types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if endpoint.IsNull() || endpoint.IsUnknown() {
+		return diags
+	}
+
+	if _, err := client.ParseEndpoint(endpoint.ValueString()); err != nil {
+		diags.AddAttributeError(
+			path.Root("endpoint"),
+			"Invalid Endpoint",
+			"The endpoint must be an absolute http or https URL without a query or fragment, such as \"https://api.example.com/v1\".\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+
+	return diags
+}
+
+// validateAuthModes returns an error diagnostic for each configured
+// authentication mode after the first, at the attribute which configures it.
+// Unknown values are not considered configured, as they are validated when
+// the provider is configured.
+func validateAuthModes(data ScaffoldingProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	type authMode struct {
+		name string
+		path path.Path
+	}
+
+	var modes []authMode
+
+	if isKnown(data.Token) {
+		modes = append(modes, authMode{name: "token", path: path.Root("token")})
+	}
+
+	switch {
+	case isKnown(data.Username):
+		modes = append(modes, authMode{name: "basic", path: path.Root("username")})
+	case isKnown(data.Password):
+		modes = append(modes, authMode{name: "basic", path: path.Root("password")})
+	}
+
+	if data.OAuth2 != nil {
+		modes = append(modes, authMode{name: "oauth2", path: path.Root("oauth2")})
+	}
+
+	for i := 1; i < len(modes); i++ {
+		diags.AddAttributeError(
+			modes[i].path,
+			"Conflicting Authentication Configuration",
+			fmt.Sprintf("The %s authentication mode conflicts with the %s authentication mode. ", modes[i].name, modes[0].name)+
+				"Configure only one of token, username and password, or oauth2.",
+		)
+	}
+
+	return diags
+}
+
+// isKnown returns whether value is neither null nor unknown.
+func isKnown(value types.String) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+// sensitiveAttributeNames returns the names of the attributes marked as
+// sensitive in any resource, ephemeral resource or data source schema, so
+// that their values can be masked when API round-trips are logged.
+func (p *ScaffoldingProvider) sensitiveAttributeNames(ctx context.Context) []string {
+	var names []string
+
+	for _, newResource := range p.Resources(ctx) {
+		var schemaResp resource.SchemaResponse
+
+		newResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		names = appendSensitiveAttributeNames(names, schemaResp.Schema.Attributes)
+	}
+
+	for _, newEphemeralResource := range p.EphemeralResources(ctx) {
+		var schemaResp ephemeral.SchemaResponse
+
+		newEphemeralResource().Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+		names = appendSensitiveAttributeNames(names, schemaResp.Schema.Attributes)
+	}
+
+	for _, newDataSource := range p.DataSources(ctx) {
+		var schemaResp datasource.SchemaResponse
+
+		newDataSource().Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+		names = appendSensitiveAttributeNames(names, schemaResp.Schema.Attributes)
+	}
+
+	slices.Sort(names)
+
+	return slices.Compact(names)
+}
+
+// appendSensitiveAttributeNames appends the names of the sensitive
+// attributes to names.
+func appendSensitiveAttributeNames[A interface{ IsSensitive() bool }](names []string, attributes map[string]A) []string {
+	for name, attribute := range attributes {
+		if attribute.IsSensitive() {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// userAgent returns the User-Agent header sent with API requests, identifying
+// the provider and Terraform versions followed by any configured suffix.
+func userAgent(providerVersion string, terraformVersion string, suffix string) string {
+	parts := []string{"terraform-provider-scaffolding/" + providerVersion}
+
+	// Terraform versions before 0.12.26 do not send their version.
+	if terraformVersion != "" {
+		parts = append(parts, "Terraform/"+terraformVersion)
+	}
+
+	if suffix != "" {
+		parts = append(parts, suffix)
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,601 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+)
+
+func TestNewClientConfig_Precedence(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "credentials")
+
+	content := `
+[default]
+endpoint = https://profile.example.com
+token    = profile-token
+
+[staging]
+username = profile-user
+password = profile-password
+`
+
+	if err := os.WriteFile(configFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := map[string]struct {
+		data        ScaffoldingProviderModel
+		env         map[string]string
+		expected    client.Config
+		expectError bool
+	}{
+		"defaults": {
+			expected: client.Config{
+				Endpoint:     client.DefaultEndpoint,
+				MaxRetries:   client.DefaultMaxRetries,
+				RetryMaxWait: client.DefaultRetryMaxWait,
+			},
+		},
+		"environment": {
+			env: map[string]string{
+				EnvEndpoint: "https://env.example.com",
+				EnvToken:    "env-token",
+			},
+			expected: client.Config{
+				Endpoint:     "https://env.example.com",
+				Token:        "env-token",
+				MaxRetries:   client.DefaultMaxRetries,
+				RetryMaxWait: client.DefaultRetryMaxWait,
+			},
+		},
+		"config-overrides-environment": {
+			data: ScaffoldingProviderModel{
+				Endpoint: types.StringValue("https://config.example.com"),
+				Token:    types.StringValue("config-token"),
+			},
+			env: map[string]string{
+				EnvEndpoint: "https://env.example.com",
+				EnvToken:    "env-token",
+			},
+			expected: client.Config{
+				Endpoint:     "https://config.example.com",
+				Token:        "config-token",
+				MaxRetries:   client.DefaultMaxRetries,
+				RetryMaxWait: client.DefaultRetryMaxWait,
+			},
+		},
+		"config-auth-ignores-environment-auth": {
+			data: ScaffoldingProviderModel{
+				Token: types.StringValue("config-token"),
+			},
+			env: map[string]string{
+				EnvUsername: "env-user",
+				EnvPassword: "env-password",
+			},
+			expected: client.Config{
+				Endpoint:     client.DefaultEndpoint,
+				Token:        "config-token",
+				MaxRetries:   client.DefaultMaxRetries,
+				RetryMaxWait: client.DefaultRetryMaxWait,
+			},
+		},
+		"environment-oauth2": {
+			env: map[string]string{
+				EnvOAuth2TokenURL:     "https://auth.example.com/token",
+				EnvOAuth2ClientID:     "env-client",
+				EnvOAuth2ClientSecret: "env-secret",
+				EnvOAuth2Scopes:       "read,write",
+			},
+			expected: client.Config{
+				Endpoint:     client.DefaultEndpoint,
+				MaxRetries:   client.DefaultMaxRetries,
+				RetryMaxWait: client.DefaultRetryMaxWait,
+				OAuth2: &client.OAuth2Config{
+					TokenURL:     "https://auth.example.com/token",
+					ClientID:     "env-client",
+					ClientSecret: "env-secret",
+					Scopes:       []string{"read", "write"},
+				},
+			},
+		},
+		"config-retries": {
+			data: ScaffoldingProviderModel{
+				MaxRetries:   types.Int64Value(0),
+				RetryMaxWait: types.StringValue("2m"),
+			},
+			expected: client.Config{
+				Endpoint:     client.DefaultEndpoint,
+				MaxRetries:   0,
+				RetryMaxWait: 2 * time.Minute,
+			},
+		},
+		"config-rate-limits": {
+			data: ScaffoldingProviderModel{
+				RequestsPerSecond:     types.Float64Value(2.5),
+				MaxConcurrentRequests: types.Int64Value(4),
+			},
+			expected: client.Config{
+				Endpoint:              client.DefaultEndpoint,
+				MaxRetries:            client.DefaultMaxRetries,
+				RetryMaxWait:          client.DefaultRetryMaxWait,
+				RequestsPerSecond:     2.5,
+				MaxConcurrentRequests: 4,
+			},
+		},
+		// Authentication is not mixed between the environment and the
+		// profile, so incomplete environment settings are an error.
+		"environment-partial-oauth2-overrides-profile": {
+			env: map[string]string{
+				EnvConfigFile:     configFile,
+				EnvOAuth2TokenURL: "https://auth.example.com/token",
+			},
+			expectError: true,
+		},
+		"profile": {
+			env: map[string]string{
+				EnvConfigFile: configFile,
+			},
+			expected: client.Config{
+				Endpoint:     "https://profile.example.com",
+				Token:        "profile-token",
+				MaxRetries:   client.DefaultMaxRetries,
+				RetryMaxWait: client.DefaultRetryMaxWait,
+			},
+		},
+		"named-profile": {
+			data: ScaffoldingProviderModel{
+				Profile:    types.StringValue("staging"),
+				ConfigFile: types.StringValue(configFile),
+			},
+			expected: client.Config{
+				Endpoint:     client.DefaultEndpoint,
+				Username:     "profile-user",
+				Password:     "profile-password",
+				MaxRetries:   client.DefaultMaxRetries,
+				RetryMaxWait: client.DefaultRetryMaxWait,
+			},
+		},
+		"environment-overrides-profile": {
+			env: map[string]string{
+				EnvConfigFile: configFile,
+				EnvEndpoint:   "https://env.example.com",
+				EnvUsername:   "env-user",
+				EnvPassword:   "env-password",
+			},
+			expected: client.Config{
+				Endpoint:     "https://env.example.com",
+				Username:     "env-user",
+				Password:     "env-password",
+				MaxRetries:   client.DefaultMaxRetries,
+				RetryMaxWait: client.DefaultRetryMaxWait,
+			},
+		},
+		"profile-not-found": {
+			data: ScaffoldingProviderModel{
+				Profile:    types.StringValue("missing"),
+				ConfigFile: types.StringValue(configFile),
+			},
+			expectError: true,
+		},
+		"config-file-not-found": {
+			env: map[string]string{
+				EnvConfigFile: filepath.Join(t.TempDir(), "missing"),
+			},
+			expectError: true,
+		},
+		"config-cache-reads": {
+			data: ScaffoldingProviderModel{
+				CacheReads: types.BoolValue(true),
+			},
+			expected: client.Config{
+				Endpoint:     client.DefaultEndpoint,
+				MaxRetries:   client.DefaultMaxRetries,
+				RetryMaxWait: client.DefaultRetryMaxWait,
+				CacheReads:   true,
+			},
+		},
+		"environment-conflicting-auth": {
+			env: map[string]string{
+				EnvToken:    "env-token",
+				EnvUsername: "env-user",
+				EnvPassword: "env-password",
+			},
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for _, envVar := range []string{EnvEndpoint, EnvToken, EnvUsername, EnvPassword, EnvOAuth2TokenURL, EnvOAuth2ClientID, EnvOAuth2ClientSecret, EnvOAuth2Scopes, EnvProfile, EnvConfigFile, EnvMock, EnvMockStateFile} {
+				t.Setenv(envVar, testCase.env[envVar])
+			}
+
+			// Ignore any credentials file in the default location.
+			t.Setenv("HOME", t.TempDir())
+
+			got, diags := newClientConfig(t.Context(), testCase.data)
+
+			if testCase.expectError {
+				if !diags.HasError() {
+					t.Fatal("expected error diagnostic, got none")
+				}
+
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+
+	if err := os.WriteFile(caCertFile, []byte("file-ca"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := map[string]struct {
+		data            ScaffoldingProviderModel
+		expected        *client.TLSConfig
+		expectWarning   bool
+		expectErrorPath string
+	}{
+		"none": {},
+		"ca-cert-pem": {
+			data: ScaffoldingProviderModel{
+				CACertPEM: types.StringValue("inline-ca"),
+			},
+			expected: &client.TLSConfig{CACertPEM: "inline-ca"},
+		},
+		"ca-cert-file": {
+			data: ScaffoldingProviderModel{
+				CACertFile: types.StringValue(caCertFile),
+			},
+			expected: &client.TLSConfig{CACertPEM: "file-ca"},
+		},
+		"ca-cert-file-missing": {
+			data: ScaffoldingProviderModel{
+				CACertFile: types.StringValue(filepath.Join(t.TempDir(), "missing.pem")),
+			},
+			expectErrorPath: "ca_cert_file",
+		},
+		"client-certificate": {
+			data: ScaffoldingProviderModel{
+				ClientCertPEM: types.StringValue("cert"),
+				ClientKeyPEM:  types.StringValue("key"),
+			},
+			expected: &client.TLSConfig{ClientCertPEM: "cert", ClientKeyPEM: "key"},
+		},
+		"insecure-skip-verify": {
+			data: ScaffoldingProviderModel{
+				InsecureSkipVerify: types.BoolValue(true),
+			},
+			expected:      &client.TLSConfig{InsecureSkipVerify: true},
+			expectWarning: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, diags := newTLSConfig(configResolver{ctx: t.Context()}, testCase.data)
+
+			if testCase.expectErrorPath != "" {
+				if !diags.HasError() {
+					t.Fatal("expected error diagnostic, got none")
+				}
+
+				withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+
+				if !ok || withPath.Path().String() != testCase.expectErrorPath {
+					t.Errorf("expected error at %s, got: %v", testCase.expectErrorPath, diags)
+				}
+
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if got := diags.WarningsCount() > 0; got != testCase.expectWarning {
+				t.Errorf("expected warning %t, got %t", testCase.expectWarning, got)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	testCases := map[string]struct {
+		terraformVersion string
+		suffix           string
+		expected         string
+	}{
+		"terraform-version": {
+			terraformVersion: "1.14.0",
+			expected:         "terraform-provider-scaffolding/1.2.3 Terraform/1.14.0",
+		},
+		"suffix": {
+			terraformVersion: "1.14.0",
+			suffix:           "ci-pipeline/42",
+			expected:         "terraform-provider-scaffolding/1.2.3 Terraform/1.14.0 ci-pipeline/42",
+		},
+		"unknown-terraform-version": {
+			expected: "terraform-provider-scaffolding/1.2.3",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := userAgent("1.2.3", testCase.terraformVersion, testCase.suffix); got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestScaffoldingProvider_ValidateConfig(t *testing.T) {
+	testCases := map[string]struct {
+		values          map[string]tftypes.Value
+		expectErrorPath string
+	}{
+		"empty": {},
+		"endpoint": {
+			values: map[string]tftypes.Value{
+				"endpoint": tftypes.NewValue(tftypes.String, "https://api.example.com/v1"),
+			},
+		},
+		"endpoint-unknown": {
+			values: map[string]tftypes.Value{
+				"endpoint": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			},
+		},
+		"endpoint-missing-scheme": {
+			values: map[string]tftypes.Value{
+				"endpoint": tftypes.NewValue(tftypes.String, "api.example.com"),
+			},
+			expectErrorPath: "endpoint",
+		},
+		"endpoint-unsupported-scheme": {
+			values: map[string]tftypes.Value{
+				"endpoint": tftypes.NewValue(tftypes.String, "ftp://api.example.com"),
+			},
+			expectErrorPath: "endpoint",
+		},
+		"endpoint-query": {
+			values: map[string]tftypes.Value{
+				"endpoint": tftypes.NewValue(tftypes.String, "https://api.example.com?region=eu"),
+			},
+			expectErrorPath: "endpoint",
+		},
+		"token-conflicts-with-basic": {
+			values: map[string]tftypes.Value{
+				"token":    tftypes.NewValue(tftypes.String, "token"),
+				"username": tftypes.NewValue(tftypes.String, "user"),
+				"password": tftypes.NewValue(tftypes.String, "password"),
+			},
+			expectErrorPath: "username",
+		},
+		"token-conflicts-with-oauth2": {
+			values: map[string]tftypes.Value{
+				"token": tftypes.NewValue(tftypes.String, "token"),
+				"oauth2": tftypes.NewValue(testOAuth2Type, map[string]tftypes.Value{
+					"token_url":     tftypes.NewValue(tftypes.String, "https://auth.example.com/token"),
+					"client_id":     tftypes.NewValue(tftypes.String, "client"),
+					"client_secret": tftypes.NewValue(tftypes.String, "secret"),
+					"scopes":        tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+				}),
+			},
+			expectErrorPath: "oauth2",
+		},
+		"token-unknown-with-basic": {
+			values: map[string]tftypes.Value{
+				"token":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"username": tftypes.NewValue(tftypes.String, "user"),
+				"password": tftypes.NewValue(tftypes.String, "password"),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := &ScaffoldingProvider{version: "test"}
+			req := provider.ValidateConfigRequest{Config: testProviderConfig(t, p, testCase.values)}
+			resp := &provider.ValidateConfigResponse{}
+
+			p.ValidateConfig(t.Context(), req, resp)
+
+			if testCase.expectErrorPath == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+
+				return
+			}
+
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Fatalf("expected one error diagnostic, got: %v", resp.Diagnostics)
+			}
+
+			withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+
+			if !ok || withPath.Path().String() != testCase.expectErrorPath {
+				t.Errorf("expected error at %s, got: %v", testCase.expectErrorPath, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// testOAuth2Type is the Terraform type of the oauth2 block.
+var testOAuth2Type = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"token_url":     tftypes.String,
+		"client_id":     tftypes.String,
+		"client_secret": tftypes.String,
+		"scopes":        tftypes.List{ElementType: tftypes.String},
+	},
+}
+
+// testProviderConfig returns a provider configuration with the given
+// attribute values, and null values for all other attributes.
+func testProviderConfig(t *testing.T, p *ScaffoldingProvider, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	var schemaResp provider.SchemaResponse
+
+	p.Schema(t.Context(), provider.SchemaRequest{}, &schemaResp)
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(t.Context()).(tftypes.Object)
+
+	if !ok {
+		t.Fatal("expected provider schema to be an object type")
+	}
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, attributes),
+	}
+}
+
+func TestScaffoldingProvider_ConfigureUnknown(t *testing.T) {
+	p := &ScaffoldingProvider{version: "test"}
+	config := testProviderConfig(t, p, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	t.Run("deferral-allowed", func(t *testing.T) {
+		req := provider.ConfigureRequest{
+			Config: config,
+			ClientCapabilities: provider.ConfigureProviderClientCapabilities{
+				DeferralAllowed: true,
+			},
+		}
+		resp := &provider.ConfigureResponse{}
+
+		p.Configure(t.Context(), req, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		if resp.Deferred == nil || resp.Deferred.Reason != provider.DeferredReasonProviderConfigUnknown {
+			t.Errorf("expected deferred response, got: %v", resp.Deferred)
+		}
+
+		if resp.ResourceData != nil {
+			t.Errorf("expected no client, got: %v", resp.ResourceData)
+		}
+	})
+
+	t.Run("deferral-not-allowed", func(t *testing.T) {
+		req := provider.ConfigureRequest{Config: config}
+		resp := &provider.ConfigureResponse{}
+
+		p.Configure(t.Context(), req, resp)
+
+		if resp.Deferred != nil {
+			t.Errorf("expected no deferred response, got: %v", resp.Deferred)
+		}
+
+		if resp.Diagnostics.ErrorsCount() != 1 {
+			t.Fatalf("expected one error diagnostic, got: %v", resp.Diagnostics)
+		}
+
+		withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+
+		if !ok || withPath.Path().String() != "endpoint" {
+			t.Errorf("expected error at endpoint, got: %v", resp.Diagnostics)
+		}
+	})
+}
+
+func TestNewMockHTTPClient(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "mock.json")
+
+	t.Setenv(EnvMock, "")
+	t.Setenv(EnvMockStateFile, "")
+
+	data := ScaffoldingProviderModel{
+		Mock:          types.BoolValue(true),
+		MockStateFile: types.StringValue(stateFile),
+	}
+
+	httpClient, diags := newMockHTTPClient(configResolver{ctx: t.Context()}, data)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	c, err := client.New(client.Config{HTTPClient: httpClient})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := c.CreateExample(t.Context(), client.Example{Defaulted: "mock"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := os.Stat(stateFile); err != nil {
+		t.Errorf("expected state file to be written: %s", err)
+	}
+
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv(EnvMock, "1")
+		t.Setenv(EnvMockStateFile, stateFile)
+
+		httpClient, diags := newMockHTTPClient(configResolver{ctx: t.Context()}, ScaffoldingProviderModel{})
+
+		if diags.HasError() || httpClient == nil {
+			t.Fatalf("expected mock HTTP client, got diagnostics: %v", diags)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		httpClient, diags := newMockHTTPClient(configResolver{ctx: t.Context()}, ScaffoldingProviderModel{})
+
+		if diags.HasError() || httpClient != nil {
+			t.Fatalf("expected no mock HTTP client, got diagnostics: %v", diags)
+		}
+	})
+
+	t.Run("invalid-environment", func(t *testing.T) {
+		t.Setenv(EnvMock, "sometimes")
+
+		if _, diags := newMockHTTPClient(configResolver{ctx: t.Context()}, ScaffoldingProviderModel{}); !diags.HasError() {
+			t.Fatal("expected error diagnostic, got none")
+		}
+	})
+}
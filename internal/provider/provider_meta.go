@@ -0,0 +1,55 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/metaschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+)
+
+// ScaffoldingProviderMetaModel describes the provider_meta data model.
+type ScaffoldingProviderMetaModel struct {
+	ModuleName types.String `tfsdk:"module_name"`
+}
+
+func (p *ScaffoldingProvider) MetaSchema(ctx context.Context, req provider.MetaSchemaRequest, resp *provider.MetaSchemaResponse) {
+	resp.Schema = metaschema.Schema{
+		Attributes: map[string]metaschema.Attribute{
+			"module_name": metaschema.StringAttribute{
+				MarkdownDescription: "Name, and optionally version, of the module managing objects, such as `example-module/1.2.3`. " +
+					"It is sent with API requests made for the module's resources and data sources to attribute usage.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// withProviderMeta returns ctx carrying the module name from the
+// provider_meta block of the module making a request, if any, so that it is
+// sent with API requests and included in logs.
+func withProviderMeta(ctx context.Context, meta tfsdk.Config) (context.Context, diag.Diagnostics) {
+	var data ScaffoldingProviderMetaModel
+
+	if meta.Raw.IsNull() {
+		return ctx, nil
+	}
+
+	diags := meta.Get(ctx, &data)
+
+	if diags.HasError() || data.ModuleName.ValueString() == "" {
+		return ctx, diags
+	}
+
+	ctx = tflog.SetField(ctx, "module_name", data.ModuleName.ValueString())
+	ctx = client.WithModuleName(ctx, data.ModuleName.ValueString())
+
+	return ctx, diags
+}
@@ -0,0 +1,86 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+)
+
+func TestWithProviderMeta(t *testing.T) {
+	var metaResp provider.MetaSchemaResponse
+
+	(&ScaffoldingProvider{}).MetaSchema(t.Context(), provider.MetaSchemaRequest{}, &metaResp)
+
+	objectType, ok := metaResp.Schema.Type().TerraformType(t.Context()).(tftypes.Object)
+
+	if !ok {
+		t.Fatal("expected provider meta schema to be an object type")
+	}
+
+	testCases := map[string]struct {
+		raw      tftypes.Value
+		expected string
+	}{
+		"absent": {
+			raw:      tftypes.NewValue(objectType, nil),
+			expected: "",
+		},
+		"null": {
+			raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"module_name": tftypes.NewValue(tftypes.String, nil),
+			}),
+			expected: "",
+		},
+		"set": {
+			raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"module_name": tftypes.NewValue(tftypes.String, "example-module/1.2.3"),
+			}),
+			expected: "example-module/1.2.3",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var moduleName string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				moduleName = r.Header.Get(client.ModuleNameHeader)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			t.Cleanup(server.Close)
+
+			c, err := client.New(client.Config{
+				Endpoint: server.URL,
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			ctx, diags := withProviderMeta(t.Context(), tfsdk.Config{
+				Schema: metaResp.Schema,
+				Raw:    testCase.raw,
+			})
+
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if err := c.DeleteExample(ctx, "example-1"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if moduleName != testCase.expected {
+				t.Errorf("expected module name %q, got %q", testCase.expected, moduleName)
+			}
+		})
+	}
+}
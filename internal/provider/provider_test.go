@@ -4,11 +4,19 @@
 package provider
 
 import (
+	"fmt"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client/clienttest"
 )
 
 // testAccProtoV6ProviderFactories is used to instantiate a provider during acceptance testing.
@@ -32,3 +40,155 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+// testAccProviderConfig returns a provider configuration block which points
+// the provider at the given API endpoint, such as a clienttest.Server URL.
+func testAccProviderConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "scaffolding" {
+  endpoint = %[1]q
+}
+`, endpoint)
+}
+
+// testAccMockProviderConfig returns a provider configuration block which
+// serves API requests from the in-process mock API, persisted to stateFile.
+func testAccMockProviderConfig(stateFile string) string {
+	return fmt.Sprintf(`
+provider "scaffolding" {
+  mock            = true
+  mock_state_file = %[1]q
+}
+`, stateFile)
+}
+
+func TestAccScaffoldingProvider_Mock(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "mock.json")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMockProviderConfig(stateFile) + `
+resource "scaffolding_example" "test" {
+  configurable_attribute = "mock"
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"scaffolding_example.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("example-1"),
+					),
+				},
+			},
+			// Objects persist between Terraform runs.
+			{
+				Config: testAccMockProviderConfig(stateFile) + `
+resource "scaffolding_example" "test" {
+  configurable_attribute = "mock"
+}
+
+data "scaffolding_example" "test" {
+  configurable_attribute = scaffolding_example.test.configurable_attribute
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.scaffolding_example.test",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("example-1"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccScaffoldingProvider_ConflictingAuth(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "scaffolding" {
+  token    = "example-token"
+  username = "example-user"
+  password = "example-password"
+}
+
+data "scaffolding_example" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Conflicting Authentication Configuration`),
+			},
+		},
+	})
+}
+
+func TestAccScaffoldingProvider_InvalidEndpoint(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProviderConfig("api.example.com") + `data "scaffolding_example" "test" {}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Endpoint`),
+			},
+		},
+	})
+}
+
+func TestAccScaffoldingProvider_InvalidDefaultTimeouts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "scaffolding" {
+  default_timeouts {
+    create = "soon"
+  }
+}
+
+data "scaffolding_example" "test" {}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Duration`),
+			},
+		},
+	})
+}
+
+func TestAccScaffoldingProvider_CACertPEM(t *testing.T) {
+	server := clienttest.NewTLSServer(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The server certificate is not trusted by default.
+			{
+				Config:      testAccProviderConfig(server.URL) + testAccScaffoldingProviderResourceConfig,
+				ExpectError: regexp.MustCompile(`certificate`),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "scaffolding" {
+  endpoint    = %[1]q
+  ca_cert_pem = %[2]q
+}
+`, server.URL, server.CACertPEM()) + testAccScaffoldingProviderResourceConfig,
+			},
+		},
+	})
+}
+
+// testAccScaffoldingProviderResourceConfig creates an example object, which
+// requires a configured provider able to reach the API.
+const testAccScaffoldingProviderResourceConfig = `
+resource "scaffolding_example" "test" {}
+`
@@ -0,0 +1,167 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ScaffoldingProviderDefaultTagsModel describes the default_tags block data
+// model.
+type ScaffoldingProviderDefaultTagsModel struct {
+	Tags types.Map `tfsdk:"tags"`
+}
+
+// ScaffoldingProviderIgnoreTagsModel describes the ignore_tags block data
+// model.
+type ScaffoldingProviderIgnoreTagsModel struct {
+	Keys        types.Set `tfsdk:"keys"`
+	KeyPrefixes types.Set `tfsdk:"key_prefixes"`
+}
+
+// tagPolicy is the provider-wide tagging configuration applied to resources
+// which support tags.
+type tagPolicy struct {
+	// defaultTags are applied to every resource, unless overridden by a tag
+	// with the same key on the resource.
+	defaultTags map[string]string
+
+	// ignoreKeys and ignoreKeyPrefixes match tags which are managed outside
+	// of Terraform. They are neither planned nor reported as drift.
+	ignoreKeys        []string
+	ignoreKeyPrefixes []string
+}
+
+// newTagPolicy returns the tag policy described by the provider data model.
+func newTagPolicy(ctx context.Context, data ScaffoldingProviderModel) (tagPolicy, diag.Diagnostics) {
+	var policy tagPolicy
+	var diags diag.Diagnostics
+
+	if data.DefaultTags != nil {
+		diags.Append(data.DefaultTags.Tags.ElementsAs(ctx, &policy.defaultTags, false)...)
+	}
+
+	if data.IgnoreTags != nil {
+		diags.Append(data.IgnoreTags.Keys.ElementsAs(ctx, &policy.ignoreKeys, false)...)
+		diags.Append(data.IgnoreTags.KeyPrefixes.ElementsAs(ctx, &policy.ignoreKeyPrefixes, false)...)
+	}
+
+	return policy, diags
+}
+
+// hasIgnored returns whether any tags are ignored.
+func (p tagPolicy) hasIgnored() bool {
+	return len(p.ignoreKeys) > 0 || len(p.ignoreKeyPrefixes) > 0
+}
+
+// ignored returns whether the tag with the given key is ignored.
+func (p tagPolicy) ignored(key string) bool {
+	if slices.Contains(p.ignoreKeys, key) {
+		return true
+	}
+
+	for _, prefix := range p.ignoreKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withoutIgnored returns tags without the ignored tags.
+func (p tagPolicy) withoutIgnored(tags map[string]string) map[string]string {
+	result := maps.Clone(tags)
+
+	maps.DeleteFunc(result, func(key string, _ string) bool {
+		return p.ignored(key)
+	})
+
+	return result
+}
+
+// merge returns all tags to apply to a resource with the given tags: the
+// default tags overridden by the resource tags, without the ignored tags.
+func (p tagPolicy) merge(resourceTags map[string]string) map[string]string {
+	result := make(map[string]string, len(p.defaultTags)+len(resourceTags))
+
+	maps.Copy(result, p.defaultTags)
+	maps.Copy(result, resourceTags)
+
+	return p.withoutIgnored(result)
+}
+
+// resourceTags returns the tags of a resource whose remote tags are remote
+// and whose configured tags were configured. Remote tags are attributed to
+// the resource unless they are ignored, or they match a default tag and are
+// not configured on the resource, so that changes to tags made outside of
+// Terraform are reported as drift.
+func (p tagPolicy) resourceTags(remote map[string]string, configured map[string]string) map[string]string {
+	result := make(map[string]string, len(remote))
+
+	for key, value := range remote {
+		if p.ignored(key) {
+			continue
+		}
+
+		if _, ok := configured[key]; !ok {
+			if defaultValue, ok := p.defaultTags[key]; ok && defaultValue == value {
+				continue
+			}
+		}
+
+		result[key] = value
+	}
+
+	return result
+}
+
+// tagsFromValue returns the tags of a known map value, and whether all of its
+// elements are known.
+func tagsFromValue(value types.Map) (map[string]string, bool) {
+	if value.IsUnknown() {
+		return nil, false
+	}
+
+	if value.IsNull() {
+		return nil, true
+	}
+
+	tags := make(map[string]string, len(value.Elements()))
+
+	for key, element := range value.Elements() {
+		tag, ok := element.(types.String)
+
+		if !ok || tag.IsUnknown() {
+			return nil, false
+		}
+
+		tags[key] = tag.ValueString()
+	}
+
+	return tags, true
+}
+
+// tagsValue returns tags as a map value. Empty tags are returned as null when
+// like is null, so that unset tags remain unset.
+func tagsValue(tags map[string]string, like types.Map) types.Map {
+	if len(tags) == 0 && like.IsNull() {
+		return types.MapNull(types.StringType)
+	}
+
+	elements := make(map[string]attr.Value, len(tags))
+
+	for key, value := range tags {
+		elements[key] = types.StringValue(value)
+	}
+
+	return types.MapValueMust(types.StringType, elements)
+}
@@ -0,0 +1,134 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTagPolicy_Merge(t *testing.T) {
+	policy := tagPolicy{
+		defaultTags: map[string]string{
+			"cost-center": "1234",
+			"owner":       "platform",
+		},
+		ignoreKeys:        []string{"managed-by"},
+		ignoreKeyPrefixes: []string{"external:"},
+	}
+
+	got := policy.merge(map[string]string{
+		"owner":         "team-a",
+		"name":          "example",
+		"managed-by":    "console",
+		"external:user": "someone",
+	})
+
+	expected := map[string]string{
+		"cost-center": "1234",
+		"owner":       "team-a",
+		"name":        "example",
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestTagPolicy_ResourceTags(t *testing.T) {
+	policy := tagPolicy{
+		defaultTags: map[string]string{
+			"cost-center": "1234",
+			"owner":       "platform",
+		},
+		ignoreKeyPrefixes: []string{"external:"},
+	}
+
+	testCases := map[string]struct {
+		remote     map[string]string
+		configured map[string]string
+		expected   map[string]string
+	}{
+		"defaults-only": {
+			remote: map[string]string{
+				"cost-center": "1234",
+				"owner":       "platform",
+			},
+			expected: map[string]string{},
+		},
+		"configured-overrides-default": {
+			remote: map[string]string{
+				"cost-center": "1234",
+				"owner":       "team-a",
+			},
+			configured: map[string]string{
+				"owner": "team-a",
+			},
+			expected: map[string]string{
+				"owner": "team-a",
+			},
+		},
+		"configured-matches-default": {
+			remote: map[string]string{
+				"cost-center": "1234",
+				"owner":       "platform",
+			},
+			configured: map[string]string{
+				"owner": "platform",
+			},
+			expected: map[string]string{
+				"owner": "platform",
+			},
+		},
+		"drift": {
+			remote: map[string]string{
+				"cost-center":   "5678",
+				"owner":         "platform",
+				"name":          "changed",
+				"external:user": "someone",
+			},
+			configured: map[string]string{
+				"name": "example",
+			},
+			expected: map[string]string{
+				"cost-center": "5678",
+				"name":        "changed",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := policy.resourceTags(testCase.remote, testCase.configured)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestTagsValue(t *testing.T) {
+	if got := tagsValue(nil, types.MapNull(types.StringType)); !got.IsNull() {
+		t.Errorf("expected null, got: %s", got)
+	}
+
+	empty := types.MapValueMust(types.StringType, nil)
+
+	if got := tagsValue(nil, empty); got.IsNull() || len(got.Elements()) != 0 {
+		t.Errorf("expected empty map, got: %s", got)
+	}
+
+	got, ok := tagsFromValue(tagsValue(map[string]string{"owner": "platform"}, empty))
+
+	if !ok {
+		t.Fatal("expected known tags")
+	}
+
+	if diff := cmp.Diff(map[string]string{"owner": "platform"}, got); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+)
+
+// Default operation timeouts, used when the provider default_timeouts block
+// does not set them.
+const (
+	DefaultCreateTimeout = 20 * time.Minute
+	DefaultReadTimeout   = 5 * time.Minute
+	DefaultUpdateTimeout = 20 * time.Minute
+	DefaultDeleteTimeout = 20 * time.Minute
+)
+
+// ScaffoldingProviderDefaultTimeoutsModel describes the default_timeouts block
+// data model.
+type ScaffoldingProviderDefaultTimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// operationTimeouts are the longest durations resource and data source
+// operations may take before they are abandoned.
+type operationTimeouts struct {
+	create time.Duration
+	read   time.Duration
+	update time.Duration
+	delete time.Duration
+}
+
+// newOperationTimeouts returns the default operation timeouts described by
+// the provider data model.
+func newOperationTimeouts(data ScaffoldingProviderModel) (operationTimeouts, diag.Diagnostics) {
+	timeouts := operationTimeouts{
+		create: DefaultCreateTimeout,
+		read:   DefaultReadTimeout,
+		update: DefaultUpdateTimeout,
+		delete: DefaultDeleteTimeout,
+	}
+
+	var diags diag.Diagnostics
+
+	if data.DefaultTimeouts == nil {
+		return timeouts, diags
+	}
+
+	for _, setting := range []struct {
+		name   string
+		value  types.String
+		target *time.Duration
+	}{
+		{"create", data.DefaultTimeouts.Create, &timeouts.create},
+		{"read", data.DefaultTimeouts.Read, &timeouts.read},
+		{"update", data.DefaultTimeouts.Update, &timeouts.update},
+		{"delete", data.DefaultTimeouts.Delete, &timeouts.delete},
+	} {
+		if setting.value.IsNull() {
+			continue
+		}
+
+		timeout, err := time.ParseDuration(setting.value.ValueString())
+
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("default_timeouts").AtName(setting.name),
+				"Invalid Timeout",
+				fmt.Sprintf("Unable to parse default_timeouts.%s as a duration: %s", setting.name, err),
+			)
+
+			continue
+		}
+
+		*setting.target = timeout
+	}
+
+	return timeouts, diags
+}
+
+// timeoutsAttribute returns the timeouts attribute of a resource supporting
+// the operations in opts. Timeouts are validated like the provider
+// default_timeouts, which rejects durations which are not positive.
+func timeoutsAttribute(ctx context.Context, opts timeouts.Opts) schema.Attribute {
+	attribute := timeouts.Attributes(ctx, opts)
+
+	nested, ok := attribute.(schema.SingleNestedAttribute)
+
+	if !ok {
+		return attribute
+	}
+
+	for name, nestedAttribute := range nested.Attributes {
+		if timeout, ok := nestedAttribute.(schema.StringAttribute); ok {
+			timeout.Validators = []validator.String{
+				durationValidator{},
+			}
+
+			nested.Attributes[name] = timeout
+		}
+	}
+
+	return nested
+}
+
+// operationTimeoutError is the cause of the cancellation of a context whose
+// operation timeout elapsed.
+type operationTimeoutError struct {
+	operation string
+	timeout   time.Duration
+}
+
+func (e *operationTimeoutError) Error() string {
+	return fmt.Sprintf("%s operation timed out after %s", e.operation, e.timeout)
+}
+
+// withOperationTimeout returns ctx with a deadline after timeout, recording
+// the named operation, such as "create", as the cause when it elapses.
+func withOperationTimeout(ctx context.Context, operation string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeoutCause(ctx, timeout, &operationTimeoutError{
+		operation: operation,
+		timeout:   timeout,
+	})
+}
+
+// addClientError adds an error diagnostic for err, returned by the client
+// when unable to perform action, such as "create example". Errors caused by
+// the timeout of the operation in ctx elapsing say which operation timed out,
+// objects changed since they were read are reported as such, and long-running
+// API operations which failed report the API's reason.
+func addClientError(ctx context.Context, diags *diag.Diagnostics, action string, err error) {
+	var timeoutErr *operationTimeoutError
+
+	if errors.As(context.Cause(ctx), &timeoutErr) {
+		diags.AddError(
+			"Operation Timed Out",
+			fmt.Sprintf("Unable to %s, the %s operation did not complete within its %s timeout. ", action, timeoutErr.operation, timeoutErr.timeout)+
+				fmt.Sprintf("To allow more time, increase the %s timeout in the resource timeouts attribute, where supported, ", timeoutErr.operation)+
+				"or in the provider default_timeouts block.",
+		)
+
+		return
+	}
+
+	if client.IsPreconditionFailed(err) {
+		diags.AddError(
+			"Object Changed Outside of Terraform",
+			fmt.Sprintf("Unable to %s, as it has changed since Terraform last read it. ", action)+
+				"The change was not made, so that changes made outside of Terraform are not overwritten. "+
+				"Run terraform apply -refresh-only to review the changes and accept them into state, then apply again.",
+		)
+
+		return
+	}
+
+	var operationErr *client.OperationError
+
+	if errors.As(err, &operationErr) {
+		diags.AddError(
+			"Operation Failed",
+			fmt.Sprintf("Unable to %s, the API reported that operation %s failed: %s", action, operationErr.OperationID, operationErr.Reason),
+		)
+
+		return
+	}
+
+	diags.AddError("Client Error", fmt.Sprintf("Unable to %s, got error: %s", action, err))
+}
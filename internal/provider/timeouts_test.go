@@ -0,0 +1,177 @@
+// Copyright IBM Corp. 2021, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/client"
+)
+
+func TestNewOperationTimeouts(t *testing.T) {
+	testCases := map[string]struct {
+		defaultTimeouts *ScaffoldingProviderDefaultTimeoutsModel
+		expected        operationTimeouts
+		expectError     bool
+	}{
+		"defaults": {
+			expected: operationTimeouts{
+				create: DefaultCreateTimeout,
+				read:   DefaultReadTimeout,
+				update: DefaultUpdateTimeout,
+				delete: DefaultDeleteTimeout,
+			},
+		},
+		"partial": {
+			defaultTimeouts: &ScaffoldingProviderDefaultTimeoutsModel{
+				Create: types.StringValue("1h"),
+				Read:   types.StringNull(),
+				Update: types.StringNull(),
+				Delete: types.StringValue("90s"),
+			},
+			expected: operationTimeouts{
+				create: time.Hour,
+				read:   DefaultReadTimeout,
+				update: DefaultUpdateTimeout,
+				delete: 90 * time.Second,
+			},
+		},
+		"invalid": {
+			defaultTimeouts: &ScaffoldingProviderDefaultTimeoutsModel{
+				Create: types.StringNull(),
+				Read:   types.StringValue("soon"),
+				Update: types.StringNull(),
+				Delete: types.StringNull(),
+			},
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, diags := newOperationTimeouts(ScaffoldingProviderModel{
+				DefaultTimeouts: testCase.defaultTimeouts,
+			})
+
+			if testCase.expectError {
+				if !diags.HasError() {
+					t.Fatal("expected error, got none")
+				}
+
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if got != testCase.expected {
+				t.Errorf("expected %+v, got %+v", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestAddClientError(t *testing.T) {
+	hang := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Hang until the test ends, as an unresponsive server would.
+		<-hang
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(hang) })
+
+	c, err := client.New(client.Config{
+		Endpoint: server.URL,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := withOperationTimeout(t.Context(), "create", 10*time.Millisecond)
+		defer cancel()
+
+		_, err := c.CreateExample(ctx, client.Example{})
+
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+
+		var diags diag.Diagnostics
+
+		addClientError(ctx, &diags, "create example", err)
+
+		if diags.ErrorsCount() != 1 {
+			t.Fatalf("expected one error, got: %v", diags)
+		}
+
+		if summary := diags[0].Summary(); summary != "Operation Timed Out" {
+			t.Errorf("expected timeout summary, got %q", summary)
+		}
+
+		if detail := diags[0].Detail(); !strings.Contains(detail, "create operation did not complete within its 10ms timeout") {
+			t.Errorf("expected detail to name the operation and timeout, got %q", detail)
+		}
+	})
+
+	t.Run("other", func(t *testing.T) {
+		ctx, cancel := withOperationTimeout(t.Context(), "create", time.Minute)
+		defer cancel()
+
+		var diags diag.Diagnostics
+
+		addClientError(ctx, &diags, "create example", errors.New("example error"))
+
+		if diags.ErrorsCount() != 1 {
+			t.Fatalf("expected one error, got: %v", diags)
+		}
+
+		if summary := diags[0].Summary(); summary != "Client Error" {
+			t.Errorf("expected client error summary, got %q", summary)
+		}
+	})
+}
+
+func TestTimeoutsAttribute(t *testing.T) {
+	attribute, ok := timeoutsAttribute(t.Context(), timeouts.Opts{
+		Create: true,
+		Delete: true,
+	}).(schema.SingleNestedAttribute)
+
+	if !ok {
+		t.Fatal("expected a single nested attribute")
+	}
+
+	if len(attribute.Attributes) != 2 {
+		t.Fatalf("expected create and delete attributes, got: %v", attribute.Attributes)
+	}
+
+	for name, nested := range attribute.Attributes {
+		timeout, ok := nested.(schema.StringAttribute)
+
+		if !ok {
+			t.Fatalf("expected %s to be a string attribute, got: %T", name, nested)
+		}
+
+		if len(timeout.Validators) != 1 {
+			t.Fatalf("expected %s to have one validator, got: %d", name, len(timeout.Validators))
+		}
+
+		if _, ok := timeout.Validators[0].(durationValidator); !ok {
+			t.Errorf("expected %s to be validated as a duration, got: %T", name, timeout.Validators[0])
+		}
+	}
+}